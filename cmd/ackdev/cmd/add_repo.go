@@ -40,7 +40,15 @@ var addRepositoryCmd = &cobra.Command{
 }
 
 func addRepository(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(ackConfigPath)
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Validate(config.OperationGithub); err != nil {
+		return err
+	}
+	repoType, err := repository.ParseRepositoryType(optAddRepoType)
 	if err != nil {
 		return err
 	}
@@ -59,7 +67,7 @@ func addRepository(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		_, err := repoManager.AddRepository(service, repository.GetRepositoryTypeFromString(optAddRepoType))
+		_, err := repoManager.AddRepository(service, repoType)
 		if err != nil {
 			return err
 		}
@@ -69,11 +77,20 @@ func addRepository(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		cfg.Repositories.Services = append(cfg.Repositories.Services, service)
-		if err := config.Save(cfg, ackConfigPath); err != nil {
+		addService := func(c *config.Config) {
+			if !util.InStrings(service, c.Repositories.Services) {
+				c.Repositories.Services = append(c.Repositories.Services, service)
+			}
+		}
+		addService(cfg)
+		err = updateConfig(func(c *config.Config) error {
+			addService(c)
+			return nil
+		})
+		if err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return syncWorkspaceIfEnabled(cfg, repoManager)
 }
@@ -0,0 +1,204 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/controller"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/link"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+const (
+	runtimeRepositoryName = "runtime"
+)
+
+var (
+	bumpTableHeaderColumns = []string{"Name", "Status", "Commit", "Reason"}
+
+	optBumpFilterExpression string
+	optBumpLocal            bool
+	optBumpBranch           string
+	optBumpCommitMessage    string
+	optBumpRegenerate       bool
+	optBumpDryRun           bool
+	optBumpMaxWorkers       int
+)
+
+func init() {
+	bumpRuntimeCmd.PersistentFlags().StringVarP(&optBumpFilterExpression, "filter", "f", "", "filter expression, only controllers are bumped")
+	bumpRuntimeCmd.PersistentFlags().BoolVar(&optBumpLocal, "local", false, "replace the runtime by the local runtime clone")
+	bumpRuntimeCmd.PersistentFlags().StringVar(&optBumpBranch, "branch", "", "branch created to commit the changes (defaults to bump-runtime-<version>)")
+	bumpRuntimeCmd.PersistentFlags().StringVarP(&optBumpCommitMessage, "message", "m", "", "commit message (defaults to \"Bump runtime to <version>\")")
+	bumpRuntimeCmd.PersistentFlags().BoolVar(&optBumpRegenerate, "generate", false, "regenerate the controllers using the local code-generator")
+	bumpRuntimeCmd.PersistentFlags().BoolVar(&optBumpDryRun, "dry-run", false, "only show the planned go.mod changes")
+	bumpRuntimeCmd.PersistentFlags().IntVarP(&optBumpMaxWorkers, "workers", "w", defaultEnsureMaxWorkers, "maximum number of controllers bumped in parallel")
+}
+
+var bumpRuntimeCmd = &cobra.Command{
+	Use:     "runtime <version>",
+	RunE:    bumpRuntime,
+	Args:    cobra.ExactArgs(1),
+	Short:   "Bump the ACK runtime version in the controllers go.mod",
+	Example: "ackdev bump runtime v0.31.0 -f name=s3-controller --generate",
+}
+
+func bumpRuntime(cmd *cobra.Command, args []string) error {
+	version := args[0]
+	if !strings.HasPrefix(version, "v") {
+		return fmt.Errorf("invalid version %s: versions must start with v, e.g v0.31.0", version)
+	}
+
+	filters, err := repository.BuildFilters(optBumpFilterExpression)
+	if err != nil {
+		return err
+	}
+	filters = append(filters, repository.TypeFilter(repository.RepositoryTypeController.String()))
+
+	_, repoManager, err := loadRepositoryManager()
+	if err != nil {
+		return err
+	}
+
+	opts := controller.BumpOptions{
+		Module:        controller.RuntimeModule,
+		Version:       version,
+		Branch:        optBumpBranch,
+		CommitMessage: optBumpCommitMessage,
+		DryRun:        optBumpDryRun,
+		Regenerate:    optBumpRegenerate,
+	}
+	if opts.Branch == "" {
+		opts.Branch = "bump-runtime-" + version
+	}
+	if opts.CommitMessage == "" {
+		opts.CommitMessage = fmt.Sprintf("Bump runtime to %s", version)
+	}
+	if optBumpLocal {
+		runtimeRepo, err := loadClonedRepository(repoManager, runtimeRepositoryName, repository.RepositoryTypeCore)
+		if err != nil {
+			return err
+		}
+		opts.ReplacePath = runtimeRepo.FullPath
+	}
+	if optBumpRegenerate && !optBumpDryRun {
+		codeGeneratorRepo, err := loadClonedRepository(repoManager, codeGeneratorRepositoryName, repository.RepositoryTypeCore)
+		if err != nil {
+			return err
+		}
+		opts.CodeGeneratorPath = codeGeneratorRepo.FullPath
+	}
+
+	// Only the cloned controllers can be bumped
+	repos := []*repository.Repository{}
+	for _, repo := range repoManager.List(filters...) {
+		if repo.GitHead != "" {
+			repos = append(repos, repo)
+		}
+	}
+
+	// Read the replace directives overwritten by --local before bumping, so
+	// that ackdev unlink can restore them
+	replaced := map[string][]string{}
+	if opts.ReplacePath != "" {
+		for _, repo := range repos {
+			content, err := ioutil.ReadFile(filepath.Join(repo.FullPath, "go.mod"))
+			if err != nil {
+				return err
+			}
+			replaced[repo.Name], err = link.ReplacedDirectives(content, opts.Module, opts.ReplacePath)
+			if err != nil {
+				return fmt.Errorf("cannot parse %s go.mod: %v", repo.Name, err)
+			}
+		}
+	}
+
+	results := controller.BumpAll(cmd.Context(), repos, opts, optBumpMaxWorkers)
+	if optBumpDryRun {
+		printBumpDiffs(results)
+	}
+	tablePrintBumpResults(results)
+
+	if opts.ReplacePath != "" && !opts.DryRun {
+		if err := recordBumpLinks(results, opts.ReplacePath, replaced); err != nil {
+			return err
+		}
+	}
+
+	if failed := results.Failed(); len(failed) > 0 {
+		return fmt.Errorf("failed to bump %d/%d controllers", len(failed), len(results))
+	}
+	return nil
+}
+
+// recordBumpLinks records the controllers bumped with --local in the links
+// state, so that ackdev unlink can remove their replace directive. replaced
+// maps the controllers to the replace directives overwritten by the bump.
+func recordBumpLinks(results controller.BumpResults, runtimePath string, replaced map[string][]string) error {
+	statePath := linksStatePath()
+	state, err := link.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if result.Status != controller.BumpStatusBumped {
+			continue
+		}
+		state.Add(&link.Link{
+			Repository: result.Repository.Name,
+			Target:     runtimeRepositoryName,
+			Module:     controller.RuntimeModule,
+			Path:       runtimePath,
+			Mode:       link.ModeReplace,
+			Replaced:   replaced[result.Repository.Name],
+			CreatedAt:  time.Now(),
+		})
+	}
+	return link.SaveState(state, statePath)
+}
+
+func printBumpDiffs(results controller.BumpResults) {
+	for _, result := range results {
+		if result.Diff == "" {
+			continue
+		}
+		fmt.Printf("--- %s/go.mod\n+++ %s/go.mod\n%s\n", result.Repository.Name, result.Repository.Name, result.Diff)
+	}
+}
+
+func tablePrintBumpResults(results controller.BumpResults) {
+	tw := newTable()
+	defer tw.Render()
+
+	tw.SetHeader(bumpTableHeaderColumns)
+
+	for _, result := range results {
+		commit := "-"
+		if result.Commit != "" {
+			commit = result.Commit[:7]
+		}
+		reason := "-"
+		if result.Err != nil {
+			reason = result.Err.Error()
+		}
+		tw.Append([]string{result.Repository.Name, string(result.Status), commit, reason})
+	}
+}
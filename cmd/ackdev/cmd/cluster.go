@@ -0,0 +1,77 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/cluster"
+)
+
+const (
+	// ackdevStateDirectoryName is the directory, in the user home directory,
+	// containing the state files written by ackdev.
+	ackdevStateDirectoryName = ".ackdev"
+	clusterStateFileName     = "cluster.yaml"
+)
+
+func init() {
+	clusterCmd.AddCommand(clusterCreateCmd)
+	clusterCmd.AddCommand(clusterDeleteCmd)
+	clusterCmd.AddCommand(clusterStatusCmd)
+}
+
+var clusterCmd = &cobra.Command{
+	Use:   "cluster",
+	Args:  cobra.NoArgs,
+	Short: "Manage the local kind cluster used to run and test controllers",
+}
+
+// clusterStatePath returns the path of the cluster state file.
+func clusterStatePath() string {
+	return filepath.Join(homeDirectory, ackdevStateDirectoryName, clusterStateFileName)
+}
+
+// clusterKubeconfigPath returns the path of the kubeconfig file of a cluster
+// created by ackdev.
+func clusterKubeconfigPath(name string) string {
+	return filepath.Join(homeDirectory, ackdevStateDirectoryName, name+".kubeconfig")
+}
+
+// resolveKubeconfig returns the kubeconfig path given as argument, or the one
+// set in KUBECONFIG, or the one of the cluster created by ackdev, or the
+// default kubeconfig path. The use of the ackdev cluster is reported to w,
+// usually stderr, so that it doesn't mix with the command output.
+func resolveKubeconfig(w io.Writer, kubeconfig string) (string, error) {
+	if kubeconfig != "" {
+		return kubeconfig, nil
+	}
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		return env, nil
+	}
+	state, err := cluster.LoadState(clusterStatePath())
+	if err == nil {
+		fmt.Fprintf(w, "Using cluster %s\n", state.Name)
+		return state.Kubeconfig, nil
+	}
+	if err != cluster.ErrNoCluster {
+		return "", err
+	}
+	return filepath.Join(homeDirectory, ".kube", "config"), nil
+}
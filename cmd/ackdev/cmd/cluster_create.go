@@ -0,0 +1,129 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/cluster"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+var (
+	optClusterCreateName              string
+	optClusterCreateKubernetesVersion string
+	optClusterCreateServices          []string
+	optClusterCreateImages            map[string]string
+	optClusterCreateAWSRegion         string
+)
+
+func init() {
+	clusterCreateCmd.PersistentFlags().StringVar(&optClusterCreateName, "name", cluster.DefaultName, "kind cluster name")
+	clusterCreateCmd.PersistentFlags().StringVar(&optClusterCreateKubernetesVersion, "kubernetes-version", "", "Kubernetes version, e.g v1.29.2 (defaults to the kind default version)")
+	clusterCreateCmd.PersistentFlags().StringSliceVarP(&optClusterCreateServices, "services", "s", nil, "service controllers to install in the cluster, e.g s3,rds")
+	clusterCreateCmd.PersistentFlags().StringToStringVar(&optClusterCreateImages, "image", nil, "locally built controller images loaded in the cluster, e.g --image s3=aws-controllers-k8s:s3-v1.0.0")
+	clusterCreateCmd.PersistentFlags().StringVar(&optClusterCreateAWSRegion, "aws-region", "", "AWS region the controllers manage resources in")
+}
+
+var clusterCreateCmd = &cobra.Command{
+	Use:     "create",
+	RunE:    createCluster,
+	Args:    cobra.NoArgs,
+	Short:   "Create a kind cluster and install service controllers in it",
+	Example: "ackdev cluster create --services s3 --image s3=aws-controllers-k8s:s3-v1.0.0",
+}
+
+func createCluster(cmd *cobra.Command, args []string) error {
+	statePath := clusterStatePath()
+	state, err := cluster.LoadState(statePath)
+	if err == nil {
+		return fmt.Errorf("cluster %s already exists, run 'ackdev cluster delete' first", state.Name)
+	}
+	if err != cluster.ErrNoCluster {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	repoManager, err := repository.NewManager(cfg)
+	if err != nil {
+		return err
+	}
+	// Check the controllers repositories before creating the cluster
+	controllerRepos := make([]*repository.Repository, 0, len(optClusterCreateServices))
+	for _, service := range optClusterCreateServices {
+		repo, err := loadClonedRepository(repoManager, service, repository.RepositoryTypeController)
+		if err != nil {
+			return err
+		}
+		controllerRepos = append(controllerRepos, repo)
+	}
+
+	state = &cluster.State{
+		Name:              optClusterCreateName,
+		KubeContext:       cluster.KubeContext(optClusterCreateName),
+		Kubeconfig:        clusterKubeconfigPath(optClusterCreateName),
+		KubernetesVersion: optClusterCreateKubernetesVersion,
+		CreatedAt:         time.Now(),
+	}
+	err = cluster.Create(&cluster.CreateOptions{
+		Name:              state.Name,
+		KubernetesVersion: state.KubernetesVersion,
+		Kubeconfig:        state.Kubeconfig,
+	})
+	if err != nil {
+		return err
+	}
+	// The state is saved before installing the controllers, so that the cluster
+	// can be deleted if an installation fails.
+	err = cluster.SaveState(state, statePath)
+	if err != nil {
+		return err
+	}
+
+	for i, service := range optClusterCreateServices {
+		image := optClusterCreateImages[service]
+		if image != "" {
+			err = cluster.LoadImage(state.Name, image)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = cluster.InstallController(&cluster.InstallOptions{
+			Service:        service,
+			ControllerPath: controllerRepos[i].FullPath,
+			Kubeconfig:     state.Kubeconfig,
+			Image:          image,
+			AWSRegion:      optClusterCreateAWSRegion,
+		})
+		if err != nil {
+			return err
+		}
+
+		state.Services = append(state.Services, service)
+		err = cluster.SaveState(state, statePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Cluster %s created, kubeconfig written to %s\n", state.Name, state.Kubeconfig)
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/cluster"
+)
+
+var clusterDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	RunE:  deleteCluster,
+	Args:  cobra.NoArgs,
+	Short: "Delete the kind cluster created by ackdev",
+}
+
+func deleteCluster(cmd *cobra.Command, args []string) error {
+	statePath := clusterStatePath()
+	state, err := cluster.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	err = cluster.Delete(state.Name, state.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(state.Kubeconfig)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	err = cluster.RemoveState(statePath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Cluster %s deleted\n", state.Name)
+	return nil
+}
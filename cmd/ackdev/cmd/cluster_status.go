@@ -0,0 +1,74 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/cluster"
+)
+
+var (
+	clusterStatusTableHeaderColumns = []string{"Name", "Status", "Context", "Kubeconfig", "Services", "Age"}
+)
+
+var clusterStatusCmd = &cobra.Command{
+	Use:   "status",
+	RunE:  printClusterStatus,
+	Args:  cobra.NoArgs,
+	Short: "Display the status of the kind cluster created by ackdev",
+}
+
+func printClusterStatus(cmd *cobra.Command, args []string) error {
+	state, err := cluster.LoadState(clusterStatePath())
+	if err == cluster.ErrNoCluster {
+		fmt.Println("No cluster created, run 'ackdev cluster create'")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	exists, err := cluster.Exists(state.Name)
+	if err != nil {
+		return err
+	}
+	status := "RUNNING"
+	if !exists {
+		status = "NOT FOUND"
+	}
+
+	services := "-"
+	if len(state.Services) > 0 {
+		services = strings.Join(state.Services, ",")
+	}
+
+	tw := newTable()
+	defer tw.Render()
+
+	tw.SetHeader(clusterStatusTableHeaderColumns)
+	tw.Append([]string{
+		state.Name,
+		status,
+		state.KubeContext,
+		state.Kubeconfig,
+		services,
+		time.Since(state.CreatedAt).Round(time.Minute).String(),
+	})
+	return nil
+}
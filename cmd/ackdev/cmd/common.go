@@ -21,10 +21,16 @@ import (
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/olekukonko/tablewriter"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
 )
 
 const (
 	ackdevConfigFileName = ".ackdev.yaml"
+	// configFileEnvVariable overrides the default configuration file path
+	configFileEnvVariable = config.EnvPrefix + "CONFIG_FILE"
+	configOverrideFlag    = "config-override"
 )
 
 var (
@@ -43,6 +49,9 @@ func init() {
 	}
 	homeDirectory = hd
 	defaultConfigPath = filepath.Join(homeDirectory, ackdevConfigFileName)
+	if path := os.Getenv(configFileEnvVariable); path != "" {
+		defaultConfigPath = path
+	}
 }
 
 func newTable() *tablewriter.Table {
@@ -62,3 +71,57 @@ func newTable() *tablewriter.Table {
 	table.SetNoWhiteSpace(true)
 	return table
 }
+
+// configLoadOptions returns the configuration layers applied on top of the
+// configuration file: the ACKDEV_* environment variables and the
+// --config-override flags.
+func configLoadOptions() config.LoadOptions {
+	return config.LoadOptions{
+		Environ:       os.Environ(),
+		Overrides:     optConfigOverrides,
+		OverridesFlag: "--" + configOverrideFlag,
+	}
+}
+
+// loadConfig resolves and validates the effective ackdev configuration.
+func loadConfig() (*config.Config, error) {
+	cfg, _, err := config.LoadWithOptions(ackConfigPath, configLoadOptions())
+	return cfg, err
+}
+
+// loadRepositoryManager loads the ackdev configuration and returns a repository
+// manager with all the configured repositories loaded. The configuration is
+// validated for the given operations.
+func loadRepositoryManager(ops ...config.Operation) (*config.Config, *repository.Manager, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Validate(ops...); err != nil {
+		return nil, nil, err
+	}
+
+	repoManager, err := repository.NewManager(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = repoManager.LoadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, repoManager, nil
+}
+
+// loadClonedRepository loads a configured repository and checks that it's
+// cloned locally.
+func loadClonedRepository(repoManager *repository.Manager, name string, t repository.RepositoryType) (*repository.Repository, error) {
+	repo, err := repoManager.LoadRepository(name, t)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load %s repository: %v", name, err)
+	}
+	if repo.GitHead == "" {
+		return nil, fmt.Errorf("%s repository is not cloned, run 'ackdev ensure repos'", repo.Name)
+	}
+	return repo, nil
+}
@@ -0,0 +1,76 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+)
+
+var (
+	optConfigGetOutputFormat string
+)
+
+func init() {
+	configGetCmd.PersistentFlags().StringVarP(&optConfigGetOutputFormat, "output", "o", "yaml", "output format (json|yaml)")
+}
+
+var configGetCmd = &cobra.Command{
+	Use:     "get [path]",
+	RunE:    getConfigValue,
+	Args:    cobra.MaximumNArgs(1),
+	Short:   "Print a configuration value designated by a dotted path",
+	Example: "ackdev config get repositories.services -o json",
+}
+
+func getConfigValue(cmd *cobra.Command, args []string) error {
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	cfg, err := config.Read(ackConfigPath)
+	if err != nil {
+		return err
+	}
+	value, err := config.Get(cfg, path)
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	switch optConfigGetOutputFormat {
+	case "json":
+		b, err = json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		b = append(b, '\n')
+	case "yaml":
+		b, err = yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported output type: %s", optConfigGetOutputFormat)
+	}
+
+	fmt.Print(string(b))
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/util"
+)
+
+var (
+	optConfigMigrateDryRun bool
+)
+
+func init() {
+	configMigrateCmd.PersistentFlags().BoolVar(&optConfigMigrateDryRun, "dry-run", false, "only print the configuration changes")
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:     "migrate",
+	RunE:    migrateConfig,
+	Args:    cobra.NoArgs,
+	Short:   "Upgrade the ackdev configuration file to the latest apiVersion",
+	Example: "ackdev config migrate --dry-run",
+}
+
+func migrateConfig(cmd *cobra.Command, args []string) error {
+	content, err := ioutil.ReadFile(ackConfigPath)
+	if err != nil {
+		return err
+	}
+	result, err := config.Migrate(content)
+	if err != nil {
+		return err
+	}
+	if !result.Migrated() {
+		fmt.Printf("%s is up to date (apiVersion %s)\n", ackConfigPath, result.From)
+		return nil
+	}
+
+	cfg, err := config.Read(ackConfigPath)
+	if err != nil {
+		return err
+	}
+	migrated, err := config.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrating %s from %s to %s:\n", ackConfigPath, result.From, result.To)
+	for _, step := range result.Steps {
+		fmt.Printf("  %s\n", step)
+	}
+	if diff := util.LineDiff(string(content), string(migrated)); diff != "" {
+		fmt.Printf("--- %s\n+++ %s\n%s\n", ackConfigPath, ackConfigPath, diff)
+	}
+	if optConfigMigrateDryRun {
+		return nil
+	}
+
+	// Save backs up the original file
+	err = config.Save(cfg, ackConfigPath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Original configuration saved to %s\n", config.BackupPath(ackConfigPath, result.From))
+	return nil
+}
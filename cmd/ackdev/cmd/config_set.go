@@ -0,0 +1,74 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+)
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <path> <value>",
+	RunE:  setConfigValue,
+	Args:  cobra.ExactArgs(2),
+	Short: "Set a configuration value designated by a dotted path",
+	Example: `ackdev config set github.forkPrefix ack-
+ackdev config set repositories.services s3,ecr,sqs
+ackdev config set run.flags aws-region=us-west-2,log-level=debug
+ackdev config set run.services.s3.flags.aws-region eu-west-1`,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:     "unset <path>",
+	RunE:    unsetConfigValue,
+	Args:    cobra.ExactArgs(1),
+	Short:   "Reset a configuration value designated by a dotted path to its default",
+	Example: "ackdev config unset run.flags.log-level",
+}
+
+func setConfigValue(cmd *cobra.Command, args []string) error {
+	return updateConfig(func(cfg *config.Config) error {
+		return config.Set(cfg, args[0], args[1])
+	})
+}
+
+func unsetConfigValue(cmd *cobra.Command, args []string) error {
+	return updateConfig(func(cfg *config.Config) error {
+		return config.Unset(cfg, args[0])
+	})
+}
+
+// updateConfig applies fn to the configuration file and saves it. The
+// environment variables and overrides aren't written to the file, but the
+// effective configuration must be valid for the file to be saved. The file
+// isn't validated when it's read, so that set and unset can fix it.
+func updateConfig(fn func(cfg *config.Config) error) error {
+	cfg, err := config.Read(ackConfigPath)
+	if err != nil {
+		return err
+	}
+	if err := fn(cfg); err != nil {
+		return err
+	}
+
+	effective := cfg.DeepCopy()
+	if err := config.ApplyLayers(effective, config.Origins{}, configLoadOptions()); err != nil {
+		return err
+	}
+	if err := effective.Validate(); err != nil {
+		return err
+	}
+	return config.Save(cfg, ackConfigPath)
+}
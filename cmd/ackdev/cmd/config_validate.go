@@ -0,0 +1,77 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+)
+
+var (
+	optConfigValidateFor []string
+)
+
+func init() {
+	configValidateCmd.PersistentFlags().StringSliceVar(&optConfigValidateFor, "for", nil, fmt.Sprintf("also check the fields required by the given operations (%s)", joinOperations(config.Operations)))
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:     "validate",
+	RunE:    validateConfig,
+	Args:    cobra.NoArgs,
+	Short:   "Check the ackdev configuration file",
+	Example: "ackdev config validate --for github",
+}
+
+func validateConfig(cmd *cobra.Command, args []string) error {
+	ops := make([]config.Operation, 0, len(optConfigValidateFor))
+	for _, op := range optConfigValidateFor {
+		ops = append(ops, config.Operation(op))
+	}
+
+	// Validate the effective configuration, the file alone may be incomplete
+	cfg, _, err := config.Resolve(ackConfigPath, configLoadOptions())
+	if err != nil {
+		return err
+	}
+
+	issues := cfg.Issues(ops...)
+	errs := 0
+	for _, issue := range issues {
+		if issue.Warning {
+			fmt.Fprintf(os.Stderr, "WARNING: %s\n", issue)
+			continue
+		}
+		errs++
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", issue)
+	}
+	if errs > 0 {
+		return fmt.Errorf("the configuration contains %d error(s)", errs)
+	}
+	fmt.Println("The configuration is valid")
+	return nil
+}
+
+func joinOperations(ops []config.Operation) string {
+	names := make([]string, 0, len(ops))
+	for _, op := range ops {
+		names = append(names, string(op))
+	}
+	return strings.Join(names, "|")
+}
@@ -14,12 +14,28 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
 	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
 )
 
+const (
+	defaultEnsureMaxWorkers = 4
+)
+
+var (
+	ensureRepositoriesTableHeaderColumns = []string{"Name", "Status", "Step", "Error"}
+
+	optEnsureMaxWorkers int
+)
+
+func init() {
+	ensureRepositoriesCmd.PersistentFlags().IntVarP(&optEnsureMaxWorkers, "workers", "w", defaultEnsureMaxWorkers, "maximum number of repositories ensured in parallel")
+}
+
 var ensureRepositoriesCmd = &cobra.Command{
 	Use:     "repo",
 	Aliases: []string{"repos", "repositories", "repository"},
@@ -29,10 +45,13 @@ var ensureRepositoriesCmd = &cobra.Command{
 }
 
 func ensureAllRepositories(cmd *cobra.Command, args []string) error {
-	cfg, err := config.Load(ackConfigPath)
+	cfg, err := loadConfig()
 	if err != nil {
 		return err
 	}
+	if err := cfg.Validate(config.OperationGithub); err != nil {
+		return err
+	}
 
 	repoManager, err := repository.NewManager(cfg)
 	if err != nil {
@@ -45,5 +64,33 @@ func ensureAllRepositories(cmd *cobra.Command, args []string) error {
 	}
 
 	ctx := cmd.Context()
-	return repoManager.EnsureAll(ctx)
+	results := repoManager.EnsureAll(ctx, optEnsureMaxWorkers)
+	tablePrintEnsureResults(results)
+
+	if err := syncWorkspaceIfEnabled(cfg, repoManager); err != nil {
+		return err
+	}
+	if failed := results.Failed(); len(failed) > 0 {
+		return fmt.Errorf("failed to ensure %d/%d repositories", len(failed), len(results))
+	}
+	return nil
+}
+
+func tablePrintEnsureResults(results repository.EnsureResults) {
+	tw := newTable()
+	defer tw.Render()
+
+	tw.SetHeader(ensureRepositoriesTableHeaderColumns)
+
+	for _, result := range results {
+		status, step, errMsg := "OK", "-", "-"
+		if result.Failed() {
+			status = "FAILED"
+			errMsg = result.Err.Error()
+			if result.Step != "" {
+				step = string(result.Step)
+			}
+		}
+		tw.Append([]string{result.Repository.Name, status, step, errMsg})
+	}
 }
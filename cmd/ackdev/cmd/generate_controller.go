@@ -0,0 +1,137 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/controller"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+const (
+	codeGeneratorRepositoryName = "code-generator"
+)
+
+var (
+	generateControllerTableHeaderColumns = []string{"File", "Change"}
+
+	optGenerateAWSSDKGoVersion string
+	optGenerateRuntimeVersion  string
+)
+
+func init() {
+	generateControllerCmd.PersistentFlags().StringVar(&optGenerateAWSSDKGoVersion, "aws-sdk-go-version", "", "aws-sdk-go version (defaults to the version pinned by the controller)")
+	generateControllerCmd.PersistentFlags().StringVar(&optGenerateRuntimeVersion, "runtime-version", "", "ACK runtime version (defaults to the version pinned by the controller)")
+}
+
+var generateControllerCmd = &cobra.Command{
+	Use:     "controller <service>",
+	Aliases: []string{"ctrl"},
+	RunE:    generateController,
+	Args:    cobra.ExactArgs(1),
+	Short:   "Generate a service controller using the local code-generator",
+	Example: "ackdev generate controller s3",
+}
+
+func generateController(cmd *cobra.Command, args []string) error {
+	service := args[0]
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	repoManager, err := repository.NewManager(cfg)
+	if err != nil {
+		return err
+	}
+
+	codeGeneratorRepo, err := loadClonedRepository(repoManager, codeGeneratorRepositoryName, repository.RepositoryTypeCore)
+	if err != nil {
+		return err
+	}
+	controllerRepo, err := loadClonedRepository(repoManager, service, repository.RepositoryTypeController)
+	if err != nil {
+		return err
+	}
+
+	versions, err := controller.ResolveVersions(controllerRepo.FullPath)
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s dependencies versions: %v", controllerRepo.Name, err)
+	}
+	if optGenerateAWSSDKGoVersion != "" {
+		versions.AWSSDKGo = optGenerateAWSSDKGoVersion
+	}
+	if optGenerateRuntimeVersion != "" {
+		versions.Runtime = optGenerateRuntimeVersion
+	}
+
+	before, err := controllerRepo.ChangedFiles()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Generating %s (aws-sdk-go %s, runtime %s)\n", controllerRepo.Name, versions.AWSSDKGo, versions.Runtime)
+	err = controller.Generate(&controller.GenerateOptions{
+		Service:           service,
+		CodeGeneratorPath: codeGeneratorRepo.FullPath,
+		ControllerPath:    controllerRepo.FullPath,
+		Versions:          versions,
+	})
+	if err != nil {
+		return err
+	}
+
+	after, err := controllerRepo.ChangedFiles()
+	if err != nil {
+		return err
+	}
+	changes := newFileChanges(before, after)
+	if len(changes) == 0 {
+		fmt.Println("No files changed")
+		return nil
+	}
+	tablePrintFileChanges(changes)
+	return nil
+}
+
+// newFileChanges returns the changes in after that weren't already in before.
+func newFileChanges(before, after []*repository.FileChange) []*repository.FileChange {
+	previous := make(map[string]string, len(before))
+	for _, change := range before {
+		previous[change.Path] = change.Change
+	}
+
+	changes := []*repository.FileChange{}
+	for _, change := range after {
+		if c, ok := previous[change.Path]; ok && c == change.Change {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func tablePrintFileChanges(changes []*repository.FileChange) {
+	tw := newTable()
+	defer tw.Render()
+
+	tw.SetHeader(generateControllerTableHeaderColumns)
+
+	for _, change := range changes {
+		tw.Append([]string{change.Path, change.Change})
+	}
+}
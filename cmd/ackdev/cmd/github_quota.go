@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	gogithub "github.com/google/go-github/v61/github"
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/credentials"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/github"
+)
+
+var (
+	githubQuotaTableHeaderColumns = []string{"Resource", "Limit", "Used", "Remaining", "Reset"}
+)
+
+var githubQuotaCmd = &cobra.Command{
+	Use:     "quota",
+	Aliases: []string{"rate-limit", "rate-limits"},
+	RunE:    printGithubQuota,
+	Args:    cobra.NoArgs,
+	Short:   "Display the remaining Github API quota",
+}
+
+func printGithubQuota(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	tokenProvider, err := credentials.NewProviderFromConfig(&cfg.Github)
+	if err != nil {
+		return err
+	}
+	ghc, err := github.NewClientFromConfig(tokenProvider, &cfg.Github)
+	if err != nil {
+		return err
+	}
+
+	limits, err := ghc.GetRateLimits(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	tablePrintGithubQuota(limits)
+	return nil
+}
+
+func tablePrintGithubQuota(limits *gogithub.RateLimits) {
+	tw := newTable()
+	defer tw.Render()
+
+	tw.SetHeader(githubQuotaTableHeaderColumns)
+
+	resources := []struct {
+		name string
+		rate *gogithub.Rate
+	}{
+		{"core", limits.Core},
+		{"search", limits.Search},
+		{"graphql", limits.GraphQL},
+	}
+	for _, resource := range resources {
+		if resource.rate == nil {
+			continue
+		}
+		reset := resource.rate.Reset.Time
+		tw.Append([]string{
+			resource.name,
+			strconv.Itoa(resource.rate.Limit),
+			strconv.Itoa(resource.rate.Limit - resource.rate.Remaining),
+			strconv.Itoa(resource.rate.Remaining),
+			fmt.Sprintf("%s (in %s)", reset.Local().Format(time.Kitchen), time.Until(reset).Round(time.Second)),
+		})
+	}
+}
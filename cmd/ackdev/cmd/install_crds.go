@@ -0,0 +1,102 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/cluster"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+var (
+	optInstallCRDsKubeconfig string
+	optInstallCRDsDryRun     bool
+)
+
+func init() {
+	installCRDsCmd.PersistentFlags().StringVar(&optInstallCRDsKubeconfig, "kubeconfig", "", "cluster kubeconfig file (defaults to the ackdev cluster, $KUBECONFIG or ~/.kube/config)")
+	installCRDsCmd.PersistentFlags().BoolVar(&optInstallCRDsDryRun, "dry-run", false, "only show the changes that would be applied")
+}
+
+var installCRDsCmd = &cobra.Command{
+	Use:     "crds <service>...",
+	Aliases: []string{"crd"},
+	RunE:    installCRDs,
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Install the CRDs and RBAC manifests of local controller clones",
+	Example: "ackdev install crds s3 rds --dry-run",
+}
+
+func installCRDs(cmd *cobra.Command, args []string) error {
+	kubeconfig, manifestDirs, err := loadManifestDirectories(cmd.ErrOrStderr(), args, optInstallCRDsKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	for i, service := range args {
+		if optInstallCRDsDryRun {
+			changed, err := cluster.DiffManifests(kubeconfig, manifestDirs[i])
+			if err != nil {
+				return err
+			}
+			if !changed {
+				fmt.Printf("%s: no changes\n", service)
+			}
+			continue
+		}
+
+		err = cluster.ApplyManifests(kubeconfig, manifestDirs[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadManifestDirectories resolves the cluster kubeconfig and returns, for each
+// service, the manifest directories of the local controller clone. The use of
+// the ackdev cluster is reported to w, see resolveKubeconfig.
+func loadManifestDirectories(w io.Writer, services []string, kubeconfig string) (string, [][]string, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", nil, err
+	}
+	repoManager, err := repository.NewManager(cfg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	manifestDirs := make([][]string, 0, len(services))
+	for _, service := range services {
+		repo, err := loadClonedRepository(repoManager, service, repository.RepositoryTypeController)
+		if err != nil {
+			return "", nil, err
+		}
+		dirs, err := cluster.ManifestDirectories(repo.FullPath)
+		if err != nil {
+			return "", nil, err
+		}
+		manifestDirs = append(manifestDirs, dirs)
+	}
+
+	kubeconfig, err = resolveKubeconfig(w, kubeconfig)
+	if err != nil {
+		return "", nil, err
+	}
+	return kubeconfig, manifestDirs, nil
+}
@@ -0,0 +1,106 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/link"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+const (
+	linksStateFileName = "links.yaml"
+)
+
+var (
+	optLinkTo        []string
+	optLinkWorkspace bool
+)
+
+func init() {
+	linkCmd.PersistentFlags().StringSliceVar(&optLinkTo, "to", nil, "service controllers linked to the local clone, e.g s3,ecr")
+	linkCmd.PersistentFlags().BoolVar(&optLinkWorkspace, "workspace", false, "link using the go.work file of the root directory instead of replace directives")
+}
+
+var linkCmd = &cobra.Command{
+	Use:     "link <runtime|code-generator>",
+	RunE:    linkControllers,
+	Args:    cobra.ExactArgs(1),
+	Short:   "Link controllers to a local runtime or code-generator clone",
+	Example: "ackdev link runtime --to s3,ecr",
+}
+
+// linksStatePath returns the path of the file recording the linked controllers.
+func linksStatePath() string {
+	return filepath.Join(homeDirectory, ackdevStateDirectoryName, linksStateFileName)
+}
+
+func linkControllers(cmd *cobra.Command, args []string) error {
+	target := args[0]
+	if _, err := link.TargetModule(target); err != nil {
+		return err
+	}
+	if len(optLinkTo) == 0 {
+		return errors.New("no controller to link, use --to to list them")
+	}
+
+	cfg, repoManager, err := loadRepositoryManager()
+	if err != nil {
+		return err
+	}
+	targetRepo, err := loadClonedRepository(repoManager, target, repository.RepositoryTypeCore)
+	if err != nil {
+		return err
+	}
+
+	mode := link.ModeReplace
+	if optLinkWorkspace {
+		mode = link.ModeWorkspace
+	}
+
+	statePath := linksStatePath()
+	state, err := link.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+	for _, service := range optLinkTo {
+		controllerRepo, err := loadClonedRepository(repoManager, service, repository.RepositoryTypeController)
+		if err != nil {
+			return err
+		}
+		l, err := link.Create(&link.Options{
+			Repository:     controllerRepo.Name,
+			RepositoryPath: controllerRepo.FullPath,
+			Target:         target,
+			TargetPath:     targetRepo.FullPath,
+			Mode:           mode,
+			RootDirectory:  cfg.RootDirectory,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot link %s to %s: %v", controllerRepo.Name, target, err)
+		}
+		state.Add(l)
+		// Save the state after each link so that it's accurate on failures
+		if err := link.SaveState(state, statePath); err != nil {
+			return err
+		}
+		fmt.Printf("Linked %s to %s (%s)\n", controllerRepo.Name, targetRepo.FullPath, mode)
+	}
+	return nil
+}
@@ -24,7 +24,7 @@ func init() {
 	listCmd.AddCommand(listRepositoriesCmd)
 	listCmd.AddCommand(getConfigCmd)
 
-	getConfigCmd.PersistentFlags().StringVarP(&optListOutputFormat, "output", "o", "yaml", "output format (json|yaml)")
+	getConfigCmd.PersistentFlags().StringVarP(&optListOutputFormat, "output", "o", "yaml", "output format (json|yaml|table), defaults to table with --show-origin")
 }
 
 var listCmd = &cobra.Command{
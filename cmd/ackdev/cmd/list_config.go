@@ -23,28 +23,55 @@ import (
 	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
 )
 
+const (
+	outputFormatTable = "table"
+)
+
+var (
+	listConfigTableHeaderColumns       = []string{"Path", "Value"}
+	listConfigOriginTableHeaderColumns = []string{"Path", "Value", "Origin"}
+
+	optListConfigShowOrigin bool
+)
+
+func init() {
+	getConfigCmd.PersistentFlags().BoolVar(&optListConfigShowOrigin, "show-origin", false, "show where each value comes from (default, file, env or flag)")
+}
+
 var getConfigCmd = &cobra.Command{
-	Use:   "config",
-	Short: "Display ackdev configuration file",
-	Args:  cobra.NoArgs,
-	RunE:  printConfig,
+	Use:     "config",
+	Short:   "Display ackdev effective configuration",
+	Args:    cobra.NoArgs,
+	RunE:    printConfig,
+	Example: "ACKDEV_GITHUB_TOKEN=token ackdev list config --show-origin",
 }
 
-func printConfig(*cobra.Command, []string) error {
-	cfg, err := config.Load(ackConfigPath)
+func printConfig(cmd *cobra.Command, _ []string) error {
+	cfg, origins, err := config.LoadWithOptions(ackConfigPath, configLoadOptions())
 	if err != nil {
 		return err
 	}
+	if optListConfigShowOrigin {
+		format := optListOutputFormat
+		// A table is easier to read than a YAML list
+		if !cmd.Flags().Changed("output") {
+			format = outputFormatTable
+		}
+		return printConfigOrigins(config.Values(cfg, origins), format)
+	}
 
 	var b []byte
 	switch optListOutputFormat {
+	case outputFormatTable:
+		tablePrintConfigValues(config.Values(cfg, origins), false)
+		return nil
 	case "json":
-		b, err = json.Marshal(cfg)
+		b, err = json.Marshal(cfg.Redacted())
 		if err != nil {
 			return err
 		}
 	case "yaml":
-		b, err = yaml.Marshal(cfg)
+		b, err = yaml.Marshal(cfg.Redacted())
 		if err != nil {
 			return err
 		}
@@ -55,3 +82,58 @@ func printConfig(*cobra.Command, []string) error {
 	fmt.Println(string(b))
 	return nil
 }
+
+func printConfigOrigins(values []*config.Value, format string) error {
+	var b []byte
+	var err error
+	switch format {
+	case outputFormatTable:
+		tablePrintConfigValues(values, true)
+		return nil
+	case "json":
+		b, err = json.Marshal(values)
+	case "yaml":
+		b, err = yaml.Marshal(values)
+	default:
+		return fmt.Errorf("unsupported output type: %s", format)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
+
+func tablePrintConfigValues(values []*config.Value, showOrigin bool) {
+	tw := newTable()
+	defer tw.Render()
+
+	if showOrigin {
+		tw.SetHeader(listConfigOriginTableHeaderColumns)
+	} else {
+		tw.SetHeader(listConfigTableHeaderColumns)
+	}
+	for _, v := range values {
+		row := []string{v.Path, formatConfigValue(v.Value)}
+		if showOrigin {
+			row = append(row, v.Origin.String())
+		}
+		tw.Append(row)
+	}
+}
+
+// formatConfigValue formats strings as is and other values as JSON. Unset
+// lists and maps are formatted as empty strings.
+func formatConfigValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	if string(b) == "null" {
+		return ""
+	}
+	return string(b)
+}
@@ -16,7 +16,6 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 
-	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
 	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
 )
 
@@ -55,7 +54,7 @@ func printRepositories(cmd *cobra.Command, args []string) error {
 }
 
 func listRepositories(filters ...repository.Filter) ([]*repository.Repository, error) {
-	cfg, err := config.Load(ackConfigPath)
+	cfg, err := loadConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,75 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+var (
+	optPRCreateBase      string
+	optPRCreateTitle     string
+	optPRCreateBody      string
+	optPRCreateTemplate  string
+	optPRCreateDraft     bool
+	optPRCreateReviewers []string
+)
+
+func init() {
+	prCreateCmd.PersistentFlags().StringVar(&optPRCreateBase, "base", repository.DefaultBaseBranch, "upstream branch the pull request is opened against")
+	prCreateCmd.PersistentFlags().StringVar(&optPRCreateTitle, "title", "", "pull request title (defaults to the latest commit subject)")
+	prCreateCmd.PersistentFlags().StringVar(&optPRCreateBody, "body", "", "pull request body (defaults to the latest commit body)")
+	prCreateCmd.PersistentFlags().StringVar(&optPRCreateTemplate, "template", "", "path to a Go template file used to render the pull request body")
+	prCreateCmd.PersistentFlags().BoolVar(&optPRCreateDraft, "draft", false, "open the pull request as a draft")
+	prCreateCmd.PersistentFlags().StringSliceVar(&optPRCreateReviewers, "reviewers", nil, "Github users requested for review")
+}
+
+var prCreateCmd = &cobra.Command{
+	Use:     "create <repository>",
+	RunE:    createPullRequest,
+	Args:    cobra.ExactArgs(1),
+	Short:   "Open a pull request from the repository current branch",
+	Example: "ackdev pr create s3 --draft --reviewers=a-hilaly",
+}
+
+func createPullRequest(cmd *cobra.Command, args []string) error {
+	_, repoManager, err := loadRepositoryManager(config.OperationGithub)
+	if err != nil {
+		return err
+	}
+
+	repo, err := repoManager.GetRepository(args[0])
+	if err != nil {
+		return fmt.Errorf("cannot find repository %s: %v", args[0], err)
+	}
+
+	ctx := cmd.Context()
+	pr, err := repoManager.CreatePullRequest(ctx, repo, repository.PullRequestOptions{
+		Base:      optPRCreateBase,
+		Title:     optPRCreateTitle,
+		Body:      optPRCreateBody,
+		Template:  optPRCreateTemplate,
+		Draft:     optPRCreateDraft,
+		Reviewers: optPRCreateReviewers,
+	})
+	if pr != nil {
+		fmt.Printf("pull request #%d opened: %s\n", pr.GetNumber(), pr.GetHTMLURL())
+	}
+	return err
+}
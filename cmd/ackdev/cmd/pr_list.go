@@ -0,0 +1,115 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+var (
+	prListTableHeaderColumns = []string{"Repository", "Number", "Title", "Review", "Mergeable", "Checks", "URL"}
+
+	optPRListFilterExpression string
+	optPRListAuthor           string
+	optPRListOutputFormat     string
+	optPRListMaxWorkers       int
+)
+
+func init() {
+	prListCmd.PersistentFlags().StringVarP(&optPRListFilterExpression, "filter", "f", "", "filter expression")
+	prListCmd.PersistentFlags().StringVar(&optPRListAuthor, "author", "", "pull requests author (defaults to github.username)")
+	prListCmd.PersistentFlags().StringVarP(&optPRListOutputFormat, "output", "o", "table", "output format (table|json|yaml)")
+	prListCmd.PersistentFlags().IntVarP(&optPRListMaxWorkers, "workers", "w", defaultEnsureMaxWorkers, "maximum number of repositories queried in parallel")
+}
+
+var prListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	RunE:    listPullRequests,
+	Args:    cobra.NoArgs,
+	Short:   "List the open pull requests across all the managed repositories",
+	Example: "ackdev pr list -f type=controller -o json",
+}
+
+func listPullRequests(cmd *cobra.Command, args []string) error {
+	filters, err := repository.BuildFilters(optPRListFilterExpression)
+	if err != nil {
+		return err
+	}
+
+	cfg, repoManager, err := loadRepositoryManager()
+	if err != nil {
+		return err
+	}
+
+	author := optPRListAuthor
+	if author == "" {
+		author = cfg.Github.Username
+	}
+
+	ctx := cmd.Context()
+	prs, listErr := repoManager.ListPullRequests(ctx, repoManager.List(filters...), author, optPRListMaxWorkers)
+
+	var b []byte
+	switch optPRListOutputFormat {
+	case "table":
+		tablePrintPullRequests(prs)
+		return listErr
+	case "json":
+		b, err = json.Marshal(prs)
+		if err != nil {
+			return err
+		}
+	case "yaml":
+		b, err = yaml.Marshal(prs)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported output type: %s", optPRListOutputFormat)
+	}
+
+	fmt.Println(string(b))
+	return listErr
+}
+
+func tablePrintPullRequests(prs []*repository.PullRequestInfo) {
+	tw := newTable()
+	defer tw.Render()
+
+	tw.SetHeader(prListTableHeaderColumns)
+
+	for _, pr := range prs {
+		review := pr.ReviewState
+		if pr.Draft {
+			review = "DRAFT"
+		}
+		tw.Append([]string{
+			pr.Repository,
+			strconv.Itoa(pr.Number),
+			pr.Title,
+			review,
+			pr.Mergeable,
+			pr.CheckStatus,
+			pr.URL,
+		})
+	}
+}
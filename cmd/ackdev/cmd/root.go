@@ -21,11 +21,13 @@ import (
 )
 
 var (
-	ackConfigPath string
+	ackConfigPath      string
+	optConfigOverrides []string
 )
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&ackConfigPath, "config-file", defaultConfigPath, "ackdev configuration file path")
+	rootCmd.PersistentFlags().StringArrayVar(&optConfigOverrides, configOverrideFlag, nil, "configuration value overriding the file and ACKDEV_* environment variables, e.g github.forkPrefix=ack- (can be repeated)")
 
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(editCmd)
@@ -33,6 +35,21 @@ func init() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(setupCmd)
 	rootCmd.AddCommand(ensureCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(prCmd)
+	rootCmd.AddCommand(githubCmd)
+	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(clusterCmd)
+	rootCmd.AddCommand(installCmd)
+	rootCmd.AddCommand(uninstallCmd)
+	rootCmd.AddCommand(testCmd)
+	rootCmd.AddCommand(bumpCmd)
+	rootCmd.AddCommand(linkCmd)
+	rootCmd.AddCommand(unlinkCmd)
+	rootCmd.AddCommand(workspaceCmd)
+	rootCmd.AddCommand(configCmd)
 }
 
 var rootCmd = &cobra.Command{
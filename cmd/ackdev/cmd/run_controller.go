@@ -0,0 +1,128 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/controller"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/util"
+)
+
+const (
+	awsRegionFlag = "aws-region"
+)
+
+var (
+	optRunFlags      map[string]string
+	optRunKubeconfig string
+	optRunAWSProfile string
+	optRunSkipBuild  bool
+	optRunWatch      bool
+)
+
+func init() {
+	runControllerCmd.PersistentFlags().StringToStringVar(&optRunFlags, "set", nil, "controller flags overriding the configured ones, e.g --set log-level=debug")
+	runControllerCmd.PersistentFlags().StringVar(&optRunKubeconfig, "kubeconfig", "", "kubeconfig file used by the controller (defaults to the ackdev cluster, $KUBECONFIG or ~/.kube/config)")
+	runControllerCmd.PersistentFlags().StringVar(&optRunAWSProfile, "aws-profile", "", "AWS profile used by the controller")
+	runControllerCmd.PersistentFlags().BoolVar(&optRunSkipBuild, "skip-build", false, "run the existing controller binary instead of building it")
+	runControllerCmd.PersistentFlags().BoolVarP(&optRunWatch, "watch", "w", false, "rebuild and restart the controller when a Go file changes")
+}
+
+var runControllerCmd = &cobra.Command{
+	Use:     "controller <service>",
+	Aliases: []string{"ctrl"},
+	RunE:    runController,
+	Args:    cobra.ExactArgs(1),
+	Short:   "Build and run a service controller locally",
+	Example: "ackdev run controller s3 --profile localstack --set log-level=debug --watch",
+}
+
+func runController(cmd *cobra.Command, args []string) error {
+	service := args[0]
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	repoManager, err := repository.NewManager(cfg)
+	if err != nil {
+		return err
+	}
+	controllerRepo, err := loadClonedRepository(repoManager, service, repository.RepositoryTypeController)
+	if err != nil {
+		return err
+	}
+
+	runConfig, err := cfg.RunConfig.Resolve(service, optRunProfile)
+	if err != nil {
+		return err
+	}
+	flags := runConfig.Flags
+	for k, v := range optRunFlags {
+		flags[k] = v
+	}
+
+	kubeconfig, err := resolveKubeconfig(cmd.ErrOrStderr(), optRunKubeconfig)
+	if err != nil {
+		return err
+	}
+	if runConfig.KubeContext != "" {
+		kubeconfig, err = util.WriteKubeconfigWithContext(kubeconfig, runConfig.KubeContext)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(kubeconfig)
+	}
+
+	env := []string{"KUBECONFIG=" + kubeconfig}
+	if region, ok := flags[awsRegionFlag]; ok {
+		env = append(env, "AWS_REGION="+region)
+	}
+	if optRunAWSProfile != "" {
+		env = append(env, "AWS_PROFILE="+optRunAWSProfile, "AWS_SDK_LOAD_CONFIG=1")
+	}
+	env = append(env, envList(runConfig.Env)...)
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Running %s %s\n", controllerRepo.Name, strings.Join(controller.FlagArgs(flags), " "))
+	return controller.Run(ctx, &controller.RunOptions{
+		ControllerPath: controllerRepo.FullPath,
+		Flags:          flags,
+		Env:            env,
+		SkipBuild:      optRunSkipBuild,
+		Watch:          optRunWatch,
+	})
+}
+
+// envList converts a map of environment variables to a list of KEY=VALUE
+// strings, sorted by key.
+func envList(env map[string]string) []string {
+	list := make([]string, 0, len(env))
+	for k, v := range env {
+		list = append(list, k+"="+v)
+	}
+	sort.Strings(list)
+	return list
+}
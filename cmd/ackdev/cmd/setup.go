@@ -43,12 +43,23 @@ var setupCmd = &cobra.Command{
 }
 
 func setupACKDev(cmd *cobra.Command, args []string) error {
-	_, err := config.Load(ackConfigPath)
+	// Don't rely on config.Load here, an invalid configuration file would be
+	// overwritten.
+	_, err := os.Stat(ackConfigPath)
 	if err == nil {
-		return fmt.Errorf("ackdev is already setup")
+		return fmt.Errorf("ackdev is already setup, see %s", ackConfigPath)
+	}
+	if !os.IsNotExist(err) {
+		return err
 	}
 
-	initialServices := strings.Split(optSetupInitialServices, ",")
+	initialServices := []string{}
+	for _, service := range strings.Split(optSetupInitialServices, ",") {
+		service = strings.TrimSpace(service)
+		if service != "" {
+			initialServices = append(initialServices, service)
+		}
+	}
 	rootDir, err := filepath.Abs(optSetupRootDirectory)
 	if err != nil {
 		return err
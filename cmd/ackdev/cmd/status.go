@@ -0,0 +1,150 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/link"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+var (
+	statusTableHeaderColumns = []string{"Name", "Branch", "Modified", "Untracked", "Origin", "Upstream", "Stashes"}
+
+	optStatusFilterExpression string
+	optStatusDirtyOnly        bool
+)
+
+func init() {
+	statusCmd.PersistentFlags().StringVarP(&optStatusFilterExpression, "filter", "f", "", "filter expression")
+	statusCmd.PersistentFlags().BoolVar(&optStatusDirtyOnly, "dirty-only", false, "only display repositories with uncommitted changes or untracked files")
+}
+
+var statusCmd = &cobra.Command{
+	Use:     "status",
+	RunE:    printStatus,
+	Args:    cobra.NoArgs,
+	Short:   "Display the status of the local repositories",
+	Example: "ackdev status -f type=controller --dirty-only",
+}
+
+type statusRecord struct {
+	repo   *repository.Repository
+	status *repository.Status
+}
+
+func printStatus(cmd *cobra.Command, args []string) error {
+	filters, err := repository.BuildFilters(optStatusFilterExpression)
+	if err != nil {
+		return err
+	}
+
+	repos, err := listRepositories(filters...)
+	if err != nil {
+		return err
+	}
+
+	records := make([]*statusRecord, 0, len(repos))
+	for _, repo := range repos {
+		status, err := repo.Status()
+		if err == repository.ErrRepositoryDoesntExist {
+			if !optStatusDirtyOnly {
+				records = append(records, &statusRecord{repo: repo})
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cannot get %s status: %v", repo.Name, err)
+		}
+		if optStatusDirtyOnly && !status.Dirty() {
+			continue
+		}
+		records = append(records, &statusRecord{repo: repo, status: status})
+	}
+
+	linksState, err := link.LoadState(linksStatePath())
+	if err != nil {
+		return err
+	}
+
+	tablePrintStatus(records)
+	return printLinkWarnings(records, linksState)
+}
+
+// printLinkWarnings warns about the repositories linked using a replace
+// directive whose go.mod is about to be committed.
+func printLinkWarnings(records []*statusRecord, state *link.State) error {
+	for _, record := range records {
+		if record.status == nil || !record.status.Dirty() {
+			continue
+		}
+		for _, l := range state.RepositoryLinks(record.repo.Name) {
+			if l.Mode != link.ModeReplace {
+				continue
+			}
+			changes, err := record.repo.ChangedFiles()
+			if err != nil {
+				return fmt.Errorf("cannot get %s changed files: %v", record.repo.Name, err)
+			}
+			for _, change := range changes {
+				if change.Path == "go.mod" {
+					fmt.Fprintf(os.Stderr, "WARNING: %s go.mod replaces %s by %s, run 'ackdev unlink %s --from %s' before committing\n",
+						record.repo.Name, l.Module, l.Path, l.Target, strings.TrimSuffix(record.repo.Name, "-controller"))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func tablePrintStatus(records []*statusRecord) {
+	tw := newTable()
+	defer tw.Render()
+
+	tw.SetHeader(statusTableHeaderColumns)
+
+	for _, record := range records {
+		if record.status == nil {
+			tw.Append([]string{record.repo.Name, "NOT CLONED", "-", "-", "-", "-", "-"})
+			continue
+		}
+		status := record.status
+		branch := status.Branch
+		if branch == "" {
+			branch = record.repo.GitHead
+		}
+		tw.Append([]string{
+			record.repo.Name,
+			branch,
+			strconv.Itoa(status.Modified),
+			strconv.Itoa(status.Untracked),
+			divergenceString(status.Origin),
+			divergenceString(status.Upstream),
+			strconv.Itoa(status.Stashes),
+		})
+	}
+}
+
+func divergenceString(d *repository.Divergence) string {
+	if d == nil {
+		return "-"
+	}
+	return d.String()
+}
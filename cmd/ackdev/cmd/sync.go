@@ -0,0 +1,93 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+var (
+	syncTableHeaderColumns = []string{"Name", "Status", "Pushed", "Reason"}
+
+	optSyncFilterExpression string
+	optSyncBranch           string
+	optSyncPush             bool
+	optSyncMaxWorkers       int
+)
+
+func init() {
+	syncCmd.PersistentFlags().StringVarP(&optSyncFilterExpression, "filter", "f", "", "filter expression")
+	syncCmd.PersistentFlags().StringVar(&optSyncBranch, "branch", repository.DefaultSyncBranch, "local branch fast-forwarded to its upstream counterpart")
+	syncCmd.PersistentFlags().BoolVar(&optSyncPush, "push", false, "push the synchronised branch to origin")
+	syncCmd.PersistentFlags().IntVarP(&optSyncMaxWorkers, "workers", "w", defaultEnsureMaxWorkers, "maximum number of repositories synchronised in parallel")
+}
+
+var syncCmd = &cobra.Command{
+	Use:     "sync",
+	RunE:    syncRepositories,
+	Args:    cobra.NoArgs,
+	Short:   "Fetch upstream and fast-forward the local default branches",
+	Example: "ackdev sync -f type=controller --push",
+}
+
+func syncRepositories(cmd *cobra.Command, args []string) error {
+	filters, err := repository.BuildFilters(optSyncFilterExpression)
+	if err != nil {
+		return err
+	}
+
+	_, repoManager, err := loadRepositoryManager()
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	opts := repository.SyncOptions{
+		Branch: optSyncBranch,
+		Push:   optSyncPush,
+	}
+	results := repoManager.SyncAll(ctx, repoManager.List(filters...), opts, optSyncMaxWorkers)
+	tablePrintSyncResults(results)
+
+	if failed := results.Failed(); len(failed) > 0 {
+		return fmt.Errorf("failed to sync %d/%d repositories", len(failed), len(results))
+	}
+	return nil
+}
+
+func tablePrintSyncResults(results repository.SyncResults) {
+	tw := newTable()
+	defer tw.Render()
+
+	tw.SetHeader(syncTableHeaderColumns)
+
+	for _, result := range results {
+		pushed := "-"
+		if result.Pushed {
+			pushed = "yes"
+		}
+		reason := result.Reason
+		if result.Err != nil {
+			reason = result.Err.Error()
+		}
+		if reason == "" {
+			reason = "-"
+		}
+		tw.Append([]string{result.Repository.Name, string(result.Status), pushed, reason})
+	}
+}
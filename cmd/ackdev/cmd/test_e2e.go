@@ -0,0 +1,122 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/test"
+)
+
+const (
+	testInfraRepositoryName = "test-infra"
+	// virtualEnvsDirectoryName is the directory, in the ackdev state directory,
+	// containing the Python virtual environments of the e2e tests.
+	virtualEnvsDirectoryName = "venvs"
+)
+
+var (
+	optTestMarkers         string
+	optTestReportDirectory string
+	optTestKubeconfig      string
+	optTestAWSProfile      string
+	optTestAWSRegion       string
+	optTestContainer       bool
+	optTestImage           string
+	optTestSkipSetup       bool
+)
+
+func init() {
+	testE2ECmd.PersistentFlags().StringVarP(&optTestMarkers, "markers", "m", "", "pytest markers expression selecting the tests to run, e.g \"canary and not slow\"")
+	testE2ECmd.PersistentFlags().StringVar(&optTestReportDirectory, "report-dir", "", "directory the JUnit XML report is written in (defaults to the configured one)")
+	testE2ECmd.PersistentFlags().StringVar(&optTestKubeconfig, "kubeconfig", "", "kubeconfig file used by the tests (defaults to the ackdev cluster, $KUBECONFIG or ~/.kube/config)")
+	testE2ECmd.PersistentFlags().StringVar(&optTestAWSProfile, "aws-profile", "", "AWS profile used by the tests")
+	testE2ECmd.PersistentFlags().StringVar(&optTestAWSRegion, awsRegionFlag, "", "AWS region used by the tests")
+	testE2ECmd.PersistentFlags().BoolVar(&optTestContainer, "container", false, "run the tests in a container instead of a local Python virtual environment")
+	testE2ECmd.PersistentFlags().StringVar(&optTestImage, "image", test.DefaultE2EImage, "container image used with --container")
+	testE2ECmd.PersistentFlags().BoolVar(&optTestSkipSetup, "skip-setup", false, "skip installing the tests requirements")
+}
+
+var testE2ECmd = &cobra.Command{
+	Use:     "e2e <service>",
+	RunE:    testE2E,
+	Args:    cobra.ExactArgs(1),
+	Short:   "Run a service controller e2e tests using the local test-infra clone",
+	Example: "ackdev test e2e s3 --markers canary --report-dir ./reports",
+}
+
+func testE2E(cmd *cobra.Command, args []string) error {
+	service := args[0]
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	repoManager, err := repository.NewManager(cfg)
+	if err != nil {
+		return err
+	}
+	controllerRepo, err := loadClonedRepository(repoManager, service, repository.RepositoryTypeController)
+	if err != nil {
+		return err
+	}
+	testInfraRepo, err := loadClonedRepository(repoManager, testInfraRepositoryName, repository.RepositoryTypeCore)
+	if err != nil {
+		return err
+	}
+
+	kubeconfig, err := resolveKubeconfig(cmd.ErrOrStderr(), optTestKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	reportDirectory := optTestReportDirectory
+	if reportDirectory == "" {
+		reportDirectory = cfg.TestConfig.GetReportDirectory()
+	}
+
+	env := []string{}
+	if optTestAWSRegion != "" {
+		env = append(env, "AWS_REGION="+optTestAWSRegion)
+	}
+	if optTestAWSProfile != "" {
+		env = append(env, "AWS_PROFILE="+optTestAWSProfile)
+	}
+
+	reportPath, err := test.RunE2E(&test.E2EOptions{
+		Service:         service,
+		ControllerPath:  controllerRepo.FullPath,
+		TestInfraPath:   testInfraRepo.FullPath,
+		Kubeconfig:      kubeconfig,
+		ReportDirectory: reportDirectory,
+		Markers:         optTestMarkers,
+		Env:             env,
+		VirtualEnvPath:  filepath.Join(homeDirectory, ackdevStateDirectoryName, virtualEnvsDirectoryName, service),
+		SkipSetup:       optTestSkipSetup,
+		Container:       optTestContainer,
+		Image:           optTestImage,
+		AWSConfigPath:   filepath.Join(homeDirectory, ".aws"),
+	})
+	if reportPath != "" {
+		if _, statErr := os.Stat(reportPath); statErr == nil {
+			fmt.Printf("JUnit report written to %s\n", reportPath)
+		}
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/test"
+)
+
+var (
+	testUnitTableHeaderColumns = []string{"Repository", "Passed", "Failed", "Skipped", "Duration", "Failed Tests"}
+
+	optTestUnitFilterExpression string
+	optTestUnitOutputFormat     string
+	optTestUnitMaxWorkers       int
+)
+
+func init() {
+	testUnitCmd.PersistentFlags().StringVarP(&optTestUnitFilterExpression, "filter", "f", "", "filter expression")
+	testUnitCmd.PersistentFlags().StringVarP(&optTestUnitOutputFormat, "output", "o", "table", "output format (table|json|junit)")
+	testUnitCmd.PersistentFlags().IntVarP(&optTestUnitMaxWorkers, "workers", "w", defaultEnsureMaxWorkers, "maximum number of repositories tested in parallel")
+}
+
+var testUnitCmd = &cobra.Command{
+	Use:     "unit",
+	RunE:    testUnit,
+	Args:    cobra.NoArgs,
+	Short:   "Run the unit tests of the managed repositories",
+	Example: "ackdev test unit -f type=controller -o junit > report.xml",
+}
+
+func testUnit(cmd *cobra.Command, args []string) error {
+	filters, err := repository.BuildFilters(optTestUnitFilterExpression)
+	if err != nil {
+		return err
+	}
+
+	_, repoManager, err := loadRepositoryManager()
+	if err != nil {
+		return err
+	}
+
+	// Only the cloned repositories can be tested
+	repos := []*repository.Repository{}
+	for _, repo := range repoManager.List(filters...) {
+		if repo.GitHead != "" {
+			repos = append(repos, repo)
+		}
+	}
+
+	results := test.RunUnitTestsAll(cmd.Context(), repos, optTestUnitMaxWorkers)
+
+	switch optTestUnitOutputFormat {
+	case "table":
+		tablePrintUnitResults(results)
+	case "json":
+		b, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	case "junit":
+		if err := test.WriteJUnitReport(os.Stdout, results); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported output type: %s", optTestUnitOutputFormat)
+	}
+
+	if !results.Succeeded() {
+		return test.ErrTestsFailed
+	}
+	return nil
+}
+
+func tablePrintUnitResults(results test.UnitResults) {
+	tw := newTable()
+	defer tw.Render()
+
+	tw.SetHeader(testUnitTableHeaderColumns)
+
+	for _, result := range results {
+		failedTests := strings.Join(result.FailedTests(), ", ")
+		if result.Err != nil {
+			failedTests = fmt.Sprintf("error: %v", result.Err)
+		}
+		tw.Append([]string{
+			result.Repository,
+			strconv.Itoa(result.Passed),
+			strconv.Itoa(result.Failed),
+			strconv.Itoa(result.Skipped),
+			result.Duration.String(),
+			failedTests,
+		})
+	}
+}
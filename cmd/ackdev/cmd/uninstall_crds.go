@@ -0,0 +1,54 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/cluster"
+)
+
+var (
+	optUninstallCRDsKubeconfig string
+	optUninstallCRDsDryRun     bool
+)
+
+func init() {
+	uninstallCRDsCmd.PersistentFlags().StringVar(&optUninstallCRDsKubeconfig, "kubeconfig", "", "cluster kubeconfig file (defaults to the ackdev cluster, $KUBECONFIG or ~/.kube/config)")
+	uninstallCRDsCmd.PersistentFlags().BoolVar(&optUninstallCRDsDryRun, "dry-run", false, "only show the resources that would be deleted")
+}
+
+var uninstallCRDsCmd = &cobra.Command{
+	Use:     "crds <service>...",
+	Aliases: []string{"crd"},
+	RunE:    uninstallCRDs,
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Uninstall the CRDs and RBAC manifests of local controller clones",
+	Example: "ackdev uninstall crds s3",
+}
+
+func uninstallCRDs(cmd *cobra.Command, args []string) error {
+	kubeconfig, manifestDirs, err := loadManifestDirectories(cmd.ErrOrStderr(), args, optUninstallCRDsKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	for i := range args {
+		err = cluster.DeleteManifests(kubeconfig, manifestDirs[i], optUninstallCRDsDryRun)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/link"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/util"
+)
+
+var (
+	optUnlinkFrom []string
+)
+
+func init() {
+	unlinkCmd.PersistentFlags().StringSliceVar(&optUnlinkFrom, "from", nil, "service controllers to unlink, e.g s3,ecr (defaults to all the linked controllers)")
+}
+
+var unlinkCmd = &cobra.Command{
+	Use:     "unlink [runtime|code-generator]",
+	RunE:    unlinkControllers,
+	Args:    cobra.MaximumNArgs(1),
+	Short:   "Unlink controllers linked with 'ackdev link'",
+	Example: "ackdev unlink runtime --from s3",
+}
+
+func unlinkControllers(cmd *cobra.Command, args []string) error {
+	target := ""
+	if len(args) == 1 {
+		target = args[0]
+	}
+	repositories := make([]string, 0, len(optUnlinkFrom))
+	for _, service := range optUnlinkFrom {
+		repositories = append(repositories, repository.NewRepository(service, repository.RepositoryTypeController).Name)
+	}
+
+	cfg, repoManager, err := loadRepositoryManager()
+	if err != nil {
+		return err
+	}
+
+	statePath := linksStatePath()
+	state, err := link.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+
+	selected := []*link.Link{}
+	for _, l := range state.Links {
+		if target != "" && l.Target != target {
+			continue
+		}
+		if len(repositories) > 0 && !util.InStrings(l.Repository, repositories) {
+			continue
+		}
+		selected = append(selected, l)
+	}
+	if len(selected) == 0 {
+		fmt.Println("No linked controllers")
+		return nil
+	}
+
+	for _, l := range selected {
+		repo, err := repoManager.GetRepository(l.Repository)
+		if err != nil {
+			return fmt.Errorf("cannot unlink %s: %v", l.Repository, err)
+		}
+		state.Remove(l.Repository, l.Target)
+		// When the workspace is managed by ackdev, the go.work file is
+		// regenerated below: dropping the modules would remove the ones
+		// it contains regardless of the link.
+		if l.Mode != link.ModeWorkspace || !cfg.Workspace.Enabled {
+			// The target stays in the workspace while other controllers use it
+			keepTarget := len(state.TargetLinks(l.Target, link.ModeWorkspace)) > 0
+			if err := link.Delete(l, repo.FullPath, cfg.RootDirectory, keepTarget); err != nil {
+				return fmt.Errorf("cannot unlink %s from %s: %v", l.Repository, l.Target, err)
+			}
+		}
+		if err := link.SaveState(state, statePath); err != nil {
+			return err
+		}
+		fmt.Printf("Unlinked %s from %s\n", l.Repository, l.Target)
+	}
+	return syncWorkspaceIfEnabled(cfg, repoManager)
+}
@@ -0,0 +1,122 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/link"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/workspace"
+)
+
+var (
+	// workspaceCoreRepositories are the core repositories always added to the
+	// workspace
+	workspaceCoreRepositories = []string{runtimeRepositoryName, codeGeneratorRepositoryName}
+)
+
+func init() {
+	workspaceCmd.AddCommand(workspaceInitCmd)
+	workspaceCmd.AddCommand(workspaceSyncCmd)
+	workspaceCmd.AddCommand(workspaceExcludeCmd)
+	workspaceCmd.AddCommand(workspaceIncludeCmd)
+}
+
+var workspaceCmd = &cobra.Command{
+	Use:     "workspace",
+	Aliases: []string{"ws"},
+	Args:    cobra.NoArgs,
+	Short:   "Manage the go.work file of the root directory",
+}
+
+// syncWorkspace regenerates the go.work file of the root directory. It contains
+// the runtime and code-generator clones, the controllers selected by the
+// workspace filter, and the controllers linked with 'ackdev link --workspace'.
+// It returns whether the file changed and the workspace modules directories.
+func syncWorkspace(cfg *config.Config, repoManager *repository.Manager) (bool, []string, error) {
+	filters, err := repository.BuildFilters(cfg.Workspace.Filter)
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid workspace filter: %v", err)
+	}
+	filters = append(filters, repository.TypeFilter(repository.RepositoryTypeController.String()))
+
+	// Load the repositories cloned since the manager was created
+	if err := repoManager.LoadAll(); err != nil {
+		return false, nil, err
+	}
+
+	repos := []*repository.Repository{}
+	for _, name := range workspaceCoreRepositories {
+		if repo, err := repoManager.GetRepository(name); err == nil {
+			repos = append(repos, repo)
+		}
+	}
+	repos = append(repos, repoManager.List(filters...)...)
+	dirs := workspace.ModuleDirs(repos, cfg.Workspace.Exclude)
+
+	state, err := link.LoadState(linksStatePath())
+	if err != nil {
+		return false, nil, err
+	}
+	for _, l := range state.Links {
+		if l.Mode != link.ModeWorkspace {
+			continue
+		}
+		if repo, err := repoManager.GetRepository(l.Repository); err == nil {
+			dirs = append(dirs, repo.FullPath, l.Path)
+		}
+	}
+
+	changed, err := workspace.Sync(cfg.RootDirectory, dirs)
+	if err != nil {
+		return false, nil, err
+	}
+	return changed, dirs, nil
+}
+
+// syncWorkspaceIfEnabled regenerates the go.work file when the workspace is
+// enabled, and reports the update.
+func syncWorkspaceIfEnabled(cfg *config.Config, repoManager *repository.Manager) error {
+	if !cfg.Workspace.Enabled {
+		return nil
+	}
+	changed, _, err := syncWorkspace(cfg, repoManager)
+	if err != nil {
+		return fmt.Errorf("cannot update workspace: %v", err)
+	}
+	if changed {
+		fmt.Printf("Updated %s\n", workspace.GoWorkPath(cfg.RootDirectory))
+	}
+	return nil
+}
+
+func printWorkspaceModules(dirs []string) {
+	tw := newTable()
+	defer tw.Render()
+
+	tw.SetHeader([]string{"Module", "Path"})
+	seen := map[string]bool{}
+	for _, dir := range dirs {
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		tw.Append([]string{filepath.Base(dir), dir})
+	}
+}
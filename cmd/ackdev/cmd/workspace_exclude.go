@@ -0,0 +1,79 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/util"
+)
+
+var workspaceExcludeCmd = &cobra.Command{
+	Use:     "exclude <repository> ...",
+	RunE:    excludeFromWorkspace,
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Remove repositories from the workspace",
+	Example: "ackdev workspace exclude ec2-controller",
+}
+
+var workspaceIncludeCmd = &cobra.Command{
+	Use:     "include <repository> ...",
+	RunE:    includeInWorkspace,
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Add back repositories removed with 'ackdev workspace exclude'",
+	Example: "ackdev workspace include ec2-controller",
+}
+
+func excludeFromWorkspace(cmd *cobra.Command, args []string) error {
+	return updateWorkspaceExclusions(func(exclude []string) []string {
+		for _, name := range args {
+			if !util.InStrings(name, exclude) {
+				exclude = append(exclude, name)
+			}
+		}
+		return exclude
+	})
+}
+
+func includeInWorkspace(cmd *cobra.Command, args []string) error {
+	return updateWorkspaceExclusions(func(exclude []string) []string {
+		kept := []string{}
+		for _, name := range exclude {
+			if !util.InStrings(name, args) {
+				kept = append(kept, name)
+			}
+		}
+		return kept
+	})
+}
+
+// updateWorkspaceExclusions updates the list of repositories excluded from the
+// workspace, saves the configuration and updates the go.work file.
+func updateWorkspaceExclusions(update func(exclude []string) []string) error {
+	cfg, repoManager, err := loadRepositoryManager()
+	if err != nil {
+		return err
+	}
+
+	cfg.Workspace.Exclude = update(cfg.Workspace.Exclude)
+	err = updateConfig(func(c *config.Config) error {
+		c.Workspace.Exclude = update(c.Workspace.Exclude)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return syncWorkspaceIfEnabled(cfg, repoManager)
+}
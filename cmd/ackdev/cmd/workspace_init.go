@@ -0,0 +1,72 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/workspace"
+)
+
+var (
+	optWorkspaceFilterExpression string
+	optWorkspaceExclude          []string
+)
+
+func init() {
+	workspaceInitCmd.PersistentFlags().StringVarP(&optWorkspaceFilterExpression, "filter", "f", "", "filter expression selecting the controllers added to the workspace")
+	workspaceInitCmd.PersistentFlags().StringSliceVar(&optWorkspaceExclude, "exclude", nil, "repositories never added to the workspace, e.g ec2-controller")
+}
+
+var workspaceInitCmd = &cobra.Command{
+	Use:     "init",
+	RunE:    initWorkspace,
+	Args:    cobra.NoArgs,
+	Short:   "Generate the go.work file of the root directory and keep it updated",
+	Example: "ackdev workspace init -f name=s3-controller",
+}
+
+func initWorkspace(cmd *cobra.Command, args []string) error {
+	if _, err := repository.BuildFilters(optWorkspaceFilterExpression); err != nil {
+		return err
+	}
+
+	cfg, repoManager, err := loadRepositoryManager()
+	if err != nil {
+		return err
+	}
+
+	enableWorkspace := func(c *config.Config) error {
+		c.Workspace.Enabled = true
+		c.Workspace.Filter = optWorkspaceFilterExpression
+		c.Workspace.Exclude = optWorkspaceExclude
+		return nil
+	}
+	enableWorkspace(cfg)
+	if err := updateConfig(enableWorkspace); err != nil {
+		return err
+	}
+
+	_, dirs, err := syncWorkspace(cfg, repoManager)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s\n", workspace.GoWorkPath(cfg.RootDirectory))
+	printWorkspaceModules(dirs)
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/workspace"
+)
+
+var workspaceSyncCmd = &cobra.Command{
+	Use:   "sync",
+	RunE:  syncWorkspaceCommand,
+	Args:  cobra.NoArgs,
+	Short: "Update the go.work file of the root directory",
+}
+
+func syncWorkspaceCommand(cmd *cobra.Command, args []string) error {
+	cfg, repoManager, err := loadRepositoryManager()
+	if err != nil {
+		return err
+	}
+
+	changed, dirs, err := syncWorkspace(cfg, repoManager)
+	if err != nil {
+		return err
+	}
+	if changed {
+		fmt.Printf("Updated %s\n", workspace.GoWorkPath(cfg.RootDirectory))
+	} else {
+		fmt.Printf("%s is up to date\n", workspace.GoWorkPath(cfg.RootDirectory))
+	}
+	printWorkspaceModules(dirs)
+	return nil
+}
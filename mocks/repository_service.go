@@ -15,22 +15,124 @@ type RepositoryService struct {
 	mock.Mock
 }
 
-// ForkRepository provides a mock function with given fields: ctx, repoName
-func (_m *RepositoryService) ForkRepository(ctx context.Context, repoName string) error {
-	ret := _m.Called(ctx, repoName)
+// CreatePullRequest provides a mock function with given fields: ctx, repoName, pr
+func (_m *RepositoryService) CreatePullRequest(ctx context.Context, repoName string, pr *v61github.NewPullRequest) (*v61github.PullRequest, error) {
+	ret := _m.Called(ctx, repoName, pr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePullRequest")
+	}
+
+	var r0 *v61github.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v61github.NewPullRequest) (*v61github.PullRequest, error)); ok {
+		return rf(ctx, repoName, pr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v61github.NewPullRequest) *v61github.PullRequest); ok {
+		r0 = rf(ctx, repoName, pr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v61github.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v61github.NewPullRequest) error); ok {
+		r1 = rf(ctx, repoName, pr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForkRepository provides a mock function with given fields: ctx, repoName, forkName
+func (_m *RepositoryService) ForkRepository(ctx context.Context, repoName string, forkName string) (*v61github.Repository, error) {
+	ret := _m.Called(ctx, repoName, forkName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ForkRepository")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, repoName)
+	var r0 *v61github.Repository
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v61github.Repository, error)); ok {
+		return rf(ctx, repoName, forkName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v61github.Repository); ok {
+		r0 = rf(ctx, repoName, forkName)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v61github.Repository)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, repoName, forkName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCombinedStatus provides a mock function with given fields: ctx, repoName, ref
+func (_m *RepositoryService) GetCombinedStatus(ctx context.Context, repoName string, ref string) (*v61github.CombinedStatus, error) {
+	ret := _m.Called(ctx, repoName, ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCombinedStatus")
+	}
+
+	var r0 *v61github.CombinedStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v61github.CombinedStatus, error)); ok {
+		return rf(ctx, repoName, ref)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v61github.CombinedStatus); ok {
+		r0 = rf(ctx, repoName, ref)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v61github.CombinedStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, repoName, ref)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPullRequest provides a mock function with given fields: ctx, repoName, number
+func (_m *RepositoryService) GetPullRequest(ctx context.Context, repoName string, number int) (*v61github.PullRequest, error) {
+	ret := _m.Called(ctx, repoName, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPullRequest")
+	}
+
+	var r0 *v61github.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*v61github.PullRequest, error)); ok {
+		return rf(ctx, repoName, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *v61github.PullRequest); ok {
+		r0 = rf(ctx, repoName, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v61github.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, repoName, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetRepository provides a mock function with given fields: ctx, owner, repoName
@@ -63,9 +165,9 @@ func (_m *RepositoryService) GetRepository(ctx context.Context, owner string, re
 	return r0, r1
 }
 
-// GetUserRepositoryFork provides a mock function with given fields: ctx, owner, repoName
-func (_m *RepositoryService) GetUserRepositoryFork(ctx context.Context, owner string, repoName string) (*v61github.Repository, error) {
-	ret := _m.Called(ctx, owner, repoName)
+// GetUserRepositoryFork provides a mock function with given fields: ctx, owner, repoName, forkName
+func (_m *RepositoryService) GetUserRepositoryFork(ctx context.Context, owner string, repoName string, forkName string) (*v61github.Repository, error) {
+	ret := _m.Called(ctx, owner, repoName, forkName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUserRepositoryFork")
@@ -73,19 +175,109 @@ func (_m *RepositoryService) GetUserRepositoryFork(ctx context.Context, owner st
 
 	var r0 *v61github.Repository
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v61github.Repository, error)); ok {
-		return rf(ctx, owner, repoName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*v61github.Repository, error)); ok {
+		return rf(ctx, owner, repoName, forkName)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v61github.Repository); ok {
-		r0 = rf(ctx, owner, repoName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *v61github.Repository); ok {
+		r0 = rf(ctx, owner, repoName, forkName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*v61github.Repository)
 		}
 	}
 
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, owner, repoName, forkName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCheckRuns provides a mock function with given fields: ctx, repoName, ref
+func (_m *RepositoryService) ListCheckRuns(ctx context.Context, repoName string, ref string) ([]*v61github.CheckRun, error) {
+	ret := _m.Called(ctx, repoName, ref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCheckRuns")
+	}
+
+	var r0 []*v61github.CheckRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*v61github.CheckRun, error)); ok {
+		return rf(ctx, repoName, ref)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*v61github.CheckRun); ok {
+		r0 = rf(ctx, repoName, ref)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v61github.CheckRun)
+		}
+	}
+
 	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, owner, repoName)
+		r1 = rf(ctx, repoName, ref)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPullRequestReviews provides a mock function with given fields: ctx, repoName, number
+func (_m *RepositoryService) ListPullRequestReviews(ctx context.Context, repoName string, number int) ([]*v61github.PullRequestReview, error) {
+	ret := _m.Called(ctx, repoName, number)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPullRequestReviews")
+	}
+
+	var r0 []*v61github.PullRequestReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*v61github.PullRequestReview, error)); ok {
+		return rf(ctx, repoName, number)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*v61github.PullRequestReview); ok {
+		r0 = rf(ctx, repoName, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v61github.PullRequestReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, repoName, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPullRequests provides a mock function with given fields: ctx, repoName, author
+func (_m *RepositoryService) ListPullRequests(ctx context.Context, repoName string, author string) ([]*v61github.PullRequest, error) {
+	ret := _m.Called(ctx, repoName, author)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPullRequests")
+	}
+
+	var r0 []*v61github.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*v61github.PullRequest, error)); ok {
+		return rf(ctx, repoName, author)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*v61github.PullRequest); ok {
+		r0 = rf(ctx, repoName, author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*v61github.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, repoName, author)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -141,6 +333,24 @@ func (_m *RepositoryService) RenameRepository(ctx context.Context, owner string,
 	return r0
 }
 
+// RequestReviewers provides a mock function with given fields: ctx, repoName, number, reviewers
+func (_m *RepositoryService) RequestReviewers(ctx context.Context, repoName string, number int, reviewers []string) error {
+	ret := _m.Called(ctx, repoName, number, reviewers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestReviewers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, []string) error); ok {
+		r0 = rf(ctx, repoName, number, reviewers)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRepositoryService creates a new instance of RepositoryService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepositoryService(t interface {
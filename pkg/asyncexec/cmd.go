@@ -2,7 +2,15 @@ package asyncexec
 
 import (
 	"bufio"
+	"errors"
+	"os"
 	"os/exec"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotStarted = errors.New("command not started")
 )
 
 // New instantiate a new Cmd object.
@@ -23,6 +31,11 @@ type Cmd struct {
 	stopCh   chan struct{}
 	stdoutCh chan []byte
 	stderrCh chan []byte
+
+	// readers tracks the goroutines reading the command outputs. The pipes
+	// are closed by exec.Cmd.Wait, which should only be called once all the
+	// outputs are read.
+	readers sync.WaitGroup
 }
 
 // Run runs the command. if streamOutput is true, it will spin
@@ -40,29 +53,15 @@ func (c *Cmd) Run() error {
 	}
 	stderrScanner := bufio.NewScanner(cmdStderrReader)
 
-	// Goroutine for stdout
-	go func() {
-		defer close(c.stdoutCh)
-		for stdoutScanner.Scan() {
-			bytes := stdoutScanner.Bytes()
-			c.stdoutCh <- bytes
-		}
-	}()
-
-	// Goroutine for stderr
-	go func() {
-		defer close(c.stderrCh)
-		for stderrScanner.Scan() {
-			bytes := stderrScanner.Bytes()
-			c.stderrCh <- bytes
-		}
-	}()
-
 	err = c.cmd.Start()
 	if err != nil {
 		return err
 	}
 
+	c.readers.Add(2)
+	go c.stream(stdoutScanner, c.stdoutCh)
+	go c.stream(stderrScanner, c.stderrCh)
+
 	// listening for stop signal
 	go func() {
 		<-c.stopCh
@@ -72,6 +71,20 @@ func (c *Cmd) Run() error {
 	return nil
 }
 
+// stream sends the lines read by scanner to ch, and closes ch once the
+// output is fully read.
+func (c *Cmd) stream(scanner *bufio.Scanner, ch chan<- []byte) {
+	defer c.readers.Done()
+	defer close(ch)
+	for scanner.Scan() {
+		// The scanner reuses its buffer, the line needs to be copied
+		// before it's sent.
+		line := make([]byte, len(scanner.Bytes()))
+		copy(line, scanner.Bytes())
+		ch <- line
+	}
+}
+
 // Exited returns true if the command exited, false otherwise.
 func (c *Cmd) Exited() bool {
 	return c.cmd.ProcessState.Exited()
@@ -92,8 +105,11 @@ func (c *Cmd) StderrStream() <-chan []byte {
 	return c.stderrCh
 }
 
-// Wait blocks until the command exits
+// Wait blocks until the command exits and its outputs are fully read. If the
+// command outputs more lines than the streams buffer size, the streams need to
+// be consumed for Wait to return.
 func (c *Cmd) Wait() error {
+	c.readers.Wait()
 	return c.cmd.Wait()
 }
 
@@ -101,3 +117,32 @@ func (c *Cmd) Wait() error {
 func (c *Cmd) Stop() {
 	c.stopCh <- struct{}{}
 }
+
+// Signal sends a signal to the process running the command.
+func (c *Cmd) Signal(sig os.Signal) error {
+	if c.cmd.Process == nil {
+		return ErrNotStarted
+	}
+	return c.cmd.Process.Signal(sig)
+}
+
+// Terminate sends sig to the process running the command and waits for the
+// command to exit. If the command is still running after gracePeriod, the process
+// is killed. done should be closed once the command exited, i.e once Wait returned.
+func (c *Cmd) Terminate(sig os.Signal, gracePeriod time.Duration, done <-chan struct{}) error {
+	err := c.Signal(sig)
+	if err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+
+	timer := time.NewTimer(gracePeriod)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		c.Stop()
+		<-done
+		return nil
+	}
+}
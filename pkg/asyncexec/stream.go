@@ -7,12 +7,16 @@ import (
 )
 
 // StreamCommand executes a given command in a context directory and streams
-// the outputs to their according stdeout/stderr.
-func StreamCommand(workDir string, command string, args []string) error {
+// the outputs to their according stdeout/stderr. env is appended to the
+// current process environment.
+func StreamCommand(workDir string, env []string, command string, args []string) error {
 	cmd := exec.Command(command, args...)
 	if workDir != "" {
 		cmd.Dir = workDir
 	}
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	acmd := New(cmd, 8)
 	err := acmd.Run()
@@ -25,7 +29,7 @@ func StreamCommand(workDir string, command string, args []string) error {
 
 	go func() {
 		for b := range acmd.StdoutStream() {
-			_, err := os.Stdout.Write(b)
+			_, err := os.Stdout.Write(append(b, '\n'))
 			if err != nil {
 				msg := fmt.Sprintf("failed to write to Stdout: %v", err)
 				// should never happen, just panic.
@@ -36,7 +40,7 @@ func StreamCommand(workDir string, command string, args []string) error {
 	}()
 	go func() {
 		for b := range acmd.StderrStream() {
-			_, err := os.Stderr.Write(b)
+			_, err := os.Stderr.Write(append(b, '\n'))
 			if err != nil {
 				msg := fmt.Sprintf("failed to write to Stderr: %v", err)
 				// should never happen, just panic.
@@ -0,0 +1,116 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/asyncexec"
+)
+
+const (
+	// DefaultNamespace is the namespace the controllers are installed in
+	DefaultNamespace = "ack-system"
+
+	kubectlBinary = "kubectl"
+	helmBinary    = "helm"
+
+	// helmChartPath is the controller Helm chart path, relative to the
+	// controller repository
+	helmChartPath = "helm"
+	// helmCRDsPath is the path of the CRDs shipped with the Helm chart,
+	// relative to the controller repository
+	helmCRDsPath = "helm/crds"
+)
+
+// InstallOptions contains the options used to install a controller in a cluster.
+type InstallOptions struct {
+	// Service is the AWS service name, e.g s3
+	Service string
+	// ControllerPath is the path of the controller local clone
+	ControllerPath string
+	// Kubeconfig is the cluster kubeconfig path
+	Kubeconfig string
+	// Namespace is the controller namespace. Defaults to DefaultNamespace.
+	Namespace string
+	// Image is the controller image, e.g aws-controllers-k8s:s3-v1.0.0. Defaults
+	// to the image of the chart.
+	Image string
+	// AWSRegion is the AWS region the controller manages resources in
+	AWSRegion string
+}
+
+// ReleaseName returns the Helm release name of a service controller.
+func ReleaseName(service string) string {
+	return fmt.Sprintf("ack-%s-controller", service)
+}
+
+// InstallController installs the CRDs and the Helm chart of a local controller
+// clone in a cluster.
+func InstallController(opts *InstallOptions) error {
+	err := asyncexec.StreamCommand("", nil, kubectlBinary, []string{
+		"--kubeconfig", opts.Kubeconfig,
+		"apply", "-f", filepath.Join(opts.ControllerPath, helmCRDsPath),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot install %s CRDs: %v", opts.Service, err)
+	}
+
+	err = asyncexec.StreamCommand("", nil, helmBinary, helmInstallArgs(opts))
+	if err != nil {
+		return fmt.Errorf("cannot install %s chart: %v", opts.Service, err)
+	}
+	return nil
+}
+
+// helmInstallArgs returns the helm arguments installing a controller chart.
+func helmInstallArgs(opts *InstallOptions) []string {
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	args := []string{
+		"upgrade", "--install", ReleaseName(opts.Service),
+		filepath.Join(opts.ControllerPath, helmChartPath),
+		"--kubeconfig", opts.Kubeconfig,
+		"--namespace", namespace,
+		"--create-namespace",
+		// CRDs are applied beforehand, helm doesn't upgrade them
+		"--skip-crds",
+	}
+	if opts.Image != "" {
+		repository, tag := splitImage(opts.Image)
+		args = append(args, "--set", "image.repository="+repository)
+		if tag != "" {
+			args = append(args, "--set", "image.tag="+tag)
+		}
+	}
+	if opts.AWSRegion != "" {
+		args = append(args, "--set", "aws.region="+opts.AWSRegion)
+	}
+	return args
+}
+
+// splitImage splits an image reference in a repository and a tag. The tag is
+// empty if the reference doesn't contain one.
+func splitImage(image string) (repository, tag string) {
+	i := strings.LastIndex(image, ":")
+	// A colon before the last slash separates a registry host and port
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return image, ""
+	}
+	return image[:i], image[i+1:]
+}
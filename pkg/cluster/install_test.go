@@ -0,0 +1,72 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelmInstallArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{
+		"upgrade", "--install", "ack-s3-controller", "/src/s3-controller/helm",
+		"--kubeconfig", "/tmp/kubeconfig",
+		"--namespace", "ack-system",
+		"--create-namespace",
+		"--skip-crds",
+	}, helmInstallArgs(&InstallOptions{
+		Service:        "s3",
+		ControllerPath: "/src/s3-controller",
+		Kubeconfig:     "/tmp/kubeconfig",
+	}))
+
+	assert.Equal([]string{
+		"upgrade", "--install", "ack-rds-controller", "/src/rds-controller/helm",
+		"--kubeconfig", "/tmp/kubeconfig",
+		"--namespace", "ack",
+		"--create-namespace",
+		"--skip-crds",
+		"--set", "image.repository=localhost:5000/rds-controller",
+		"--set", "image.tag=dev",
+		"--set", "aws.region=us-west-2",
+	}, helmInstallArgs(&InstallOptions{
+		Service:        "rds",
+		ControllerPath: "/src/rds-controller",
+		Kubeconfig:     "/tmp/kubeconfig",
+		Namespace:      "ack",
+		Image:          "localhost:5000/rds-controller:dev",
+		AWSRegion:      "us-west-2",
+	}))
+}
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image          string
+		wantRepository string
+		wantTag        string
+	}{
+		{"aws-controllers-k8s:s3-v1.0.0", "aws-controllers-k8s", "s3-v1.0.0"},
+		{"public.ecr.aws/aws-controllers-k8s/s3-controller", "public.ecr.aws/aws-controllers-k8s/s3-controller", ""},
+		{"localhost:5000/s3-controller", "localhost:5000/s3-controller", ""},
+		{"localhost:5000/s3-controller:dev", "localhost:5000/s3-controller", "dev"},
+	}
+	for _, tt := range tests {
+		repository, tag := splitImage(tt.image)
+		assert.Equal(t, tt.wantRepository, repository, tt.image)
+		assert.Equal(t, tt.wantTag, tag, tt.image)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/asyncexec"
+)
+
+const (
+	// DefaultName is the default kind cluster name
+	DefaultName = "ack"
+
+	kindBinary = "kind"
+	// kindNodeImage is the kind node image, the Kubernetes version is used as
+	// image tag.
+	kindNodeImage = "kindest/node"
+)
+
+var kindConfigTemplate = template.Must(template.New("kind").Parse(`kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+name: {{ .Name }}
+nodes:
+- role: control-plane
+{{- if .KubernetesVersion }}
+  image: {{ .NodeImage }}:{{ .KubernetesVersion }}
+{{- end }}
+`))
+
+// CreateOptions contains the options used to create a kind cluster.
+type CreateOptions struct {
+	// Name is the cluster name. Defaults to DefaultName.
+	Name string
+	// KubernetesVersion is the cluster Kubernetes version, e.g v1.29.2.
+	// Defaults to the kind default version.
+	KubernetesVersion string
+	// Kubeconfig is the path of the kubeconfig file written by kind.
+	Kubeconfig string
+}
+
+// KubeContext returns the kubeconfig context of a kind cluster.
+func KubeContext(name string) string {
+	return "kind-" + name
+}
+
+// GenerateKindConfig generates the kind configuration of a cluster.
+func GenerateKindConfig(opts *CreateOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	err := kindConfigTemplate.Execute(&buf, struct {
+		*CreateOptions
+		NodeImage string
+	}{opts, kindNodeImage})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Create creates a kind cluster. kind output is streamed to stdout/stderr.
+func Create(opts *CreateOptions) error {
+	if opts.Name == "" {
+		opts.Name = DefaultName
+	}
+	kindConfig, err := GenerateKindConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile("", "ackdev-kind-config-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(kindConfig)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	args := []string{"create", "cluster", "--name", opts.Name, "--config", f.Name()}
+	if opts.Kubeconfig != "" {
+		args = append(args, "--kubeconfig", opts.Kubeconfig)
+	}
+	err = asyncexec.StreamCommand("", nil, kindBinary, args)
+	if err != nil {
+		return fmt.Errorf("cannot create cluster %s: %v", opts.Name, err)
+	}
+	return nil
+}
+
+// Delete deletes a kind cluster.
+func Delete(name, kubeconfig string) error {
+	args := []string{"delete", "cluster", "--name", name}
+	if kubeconfig != "" {
+		args = append(args, "--kubeconfig", kubeconfig)
+	}
+	err := asyncexec.StreamCommand("", nil, kindBinary, args)
+	if err != nil {
+		return fmt.Errorf("cannot delete cluster %s: %v", name, err)
+	}
+	return nil
+}
+
+// Exists returns true if a kind cluster with the given name exists.
+func Exists(name string) (bool, error) {
+	output, err := exec.Command(kindBinary, "get", "clusters").Output()
+	if err != nil {
+		return false, fmt.Errorf("cannot list kind clusters: %v", err)
+	}
+	for _, cluster := range strings.Fields(string(output)) {
+		if cluster == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// LoadImage loads a local docker image into the kind cluster nodes.
+func LoadImage(name, image string) error {
+	err := asyncexec.StreamCommand("", nil, kindBinary, []string{"load", "docker-image", image, "--name", name})
+	if err != nil {
+		return fmt.Errorf("cannot load image %s: %v", image, err)
+	}
+	return nil
+}
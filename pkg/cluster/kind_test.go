@@ -0,0 +1,47 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateKindConfig(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config, err := GenerateKindConfig(&CreateOptions{Name: "ack"})
+	require.NoError(err)
+	assert.Equal(`kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+name: ack
+nodes:
+- role: control-plane
+`, string(config))
+
+	config, err = GenerateKindConfig(&CreateOptions{Name: "ack-e2e", KubernetesVersion: "v1.29.2"})
+	require.NoError(err)
+	assert.Equal(`kind: Cluster
+apiVersion: kind.x-k8s.io/v1alpha4
+name: ack-e2e
+nodes:
+- role: control-plane
+  image: kindest/node:v1.29.2
+`, string(config))
+
+	assert.Equal("kind-ack", KubeContext("ack"))
+}
@@ -0,0 +1,106 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/asyncexec"
+)
+
+const (
+	// crdManifestsPath is the kustomize directory of the controller CRDs,
+	// relative to the controller repository
+	crdManifestsPath = "config/crd"
+	// rbacManifestsPath is the kustomize directory of the controller RBAC
+	// manifests, relative to the controller repository
+	rbacManifestsPath = "config/rbac"
+)
+
+var (
+	ErrCRDsNotFound = errors.New("CRDs directory not found")
+)
+
+// ManifestDirectories returns the kustomize directories containing the CRDs and
+// the RBAC manifests of a local controller clone. It returns ErrCRDsNotFound if
+// the controller doesn't have a CRDs directory, the RBAC directory is optional.
+func ManifestDirectories(controllerPath string) ([]string, error) {
+	crdDir := filepath.Join(controllerPath, crdManifestsPath)
+	if !isDirectory(crdDir) {
+		return nil, fmt.Errorf("%w: %s", ErrCRDsNotFound, crdDir)
+	}
+	dirs := []string{crdDir}
+
+	rbacDir := filepath.Join(controllerPath, rbacManifestsPath)
+	if isDirectory(rbacDir) {
+		dirs = append(dirs, rbacDir)
+	}
+	return dirs, nil
+}
+
+// ApplyManifests applies kustomize directories to a cluster.
+func ApplyManifests(kubeconfig string, dirs []string) error {
+	for _, dir := range dirs {
+		err := asyncexec.StreamCommand("", nil, kubectlBinary, []string{"--kubeconfig", kubeconfig, "apply", "-k", dir})
+		if err != nil {
+			return fmt.Errorf("cannot apply %s: %v", dir, err)
+		}
+	}
+	return nil
+}
+
+// DiffManifests shows the changes applying kustomize directories would make to
+// a cluster. It returns true if there are changes.
+func DiffManifests(kubeconfig string, dirs []string) (bool, error) {
+	changed := false
+	for _, dir := range dirs {
+		err := asyncexec.StreamCommand("", nil, kubectlBinary, []string{"--kubeconfig", kubeconfig, "diff", "-k", dir})
+		// kubectl diff exits with code 1 when there are differences
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			changed = true
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("cannot diff %s: %v", dir, err)
+		}
+	}
+	return changed, nil
+}
+
+// DeleteManifests deletes the resources of kustomize directories from a cluster,
+// in the reverse order of the directories. If dryRun is true, the resources that
+// would be deleted are only displayed.
+func DeleteManifests(kubeconfig string, dirs []string, dryRun bool) error {
+	for i := len(dirs) - 1; i >= 0; i-- {
+		args := []string{"--kubeconfig", kubeconfig, "delete", "-k", dirs[i], "--ignore-not-found"}
+		if dryRun {
+			args = append(args, "--dry-run=server")
+		}
+		err := asyncexec.StreamCommand("", nil, kubectlBinary, args)
+		if err != nil {
+			return fmt.Errorf("cannot delete %s: %v", dirs[i], err)
+		}
+	}
+	return nil
+}
+
+func isDirectory(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestDirectories(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	controllerPath := t.TempDir()
+	_, err := ManifestDirectories(controllerPath)
+	assert.True(errors.Is(err, ErrCRDsNotFound))
+
+	require.NoError(os.MkdirAll(filepath.Join(controllerPath, "config", "crd"), 0755))
+	dirs, err := ManifestDirectories(controllerPath)
+	require.NoError(err)
+	assert.Equal([]string{filepath.Join(controllerPath, "config", "crd")}, dirs)
+
+	require.NoError(os.MkdirAll(filepath.Join(controllerPath, "config", "rbac"), 0755))
+	dirs, err = ManifestDirectories(controllerPath)
+	require.NoError(err)
+	assert.Equal([]string{
+		filepath.Join(controllerPath, "config", "crd"),
+		filepath.Join(controllerPath, "config", "rbac"),
+	}, dirs)
+}
@@ -0,0 +1,88 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ghodss/yaml"
+)
+
+var (
+	ErrNoCluster = errors.New("no cluster created by ackdev")
+)
+
+// State records the local cluster created by ackdev, allowing other commands to
+// target it.
+type State struct {
+	// Name is the kind cluster name
+	Name string `yaml:"name" json:"name"`
+	// KubeContext is the cluster kubeconfig context
+	KubeContext string `yaml:"kubeContext" json:"kubeContext"`
+	// Kubeconfig is the path of the cluster kubeconfig file
+	Kubeconfig string `yaml:"kubeconfig" json:"kubeconfig"`
+	// KubernetesVersion is the cluster Kubernetes version. Empty if the
+	// kind default version is used.
+	KubernetesVersion string `yaml:"kubernetesVersion,omitempty" json:"kubernetesVersion,omitempty"`
+	// Services is the list of service controllers installed in the cluster
+	Services []string `yaml:"services,omitempty" json:"services,omitempty"`
+	// CreatedAt is the cluster creation time
+	CreatedAt time.Time `yaml:"createdAt" json:"createdAt"`
+}
+
+// LoadState reads the cluster state file. It returns ErrNoCluster if the file
+// doesn't exist.
+func LoadState(statePath string) (*State, error) {
+	content, err := ioutil.ReadFile(statePath)
+	if os.IsNotExist(err) {
+		return nil, ErrNoCluster
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{}
+	err = yaml.Unmarshal(content, state)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// SaveState writes the cluster state file, creating its parent directory if
+// needed.
+func SaveState(state *State, statePath string) error {
+	bytes, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(statePath), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(statePath, bytes, 0644)
+}
+
+// RemoveState removes the cluster state file.
+func RemoveState(statePath string) error {
+	err := os.Remove(statePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
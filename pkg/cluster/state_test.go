@@ -0,0 +1,49 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cluster
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	statePath := filepath.Join(t.TempDir(), ".ackdev", "cluster.yaml")
+	_, err := LoadState(statePath)
+	assert.Equal(ErrNoCluster, err)
+
+	state := &State{
+		Name:        "ack",
+		KubeContext: "kind-ack",
+		Kubeconfig:  "/home/ack/.ackdev/kind-ack.kubeconfig",
+		Services:    []string{"s3", "rds"},
+		CreatedAt:   time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+	require.NoError(SaveState(state, statePath))
+	loaded, err := LoadState(statePath)
+	require.NoError(err)
+	assert.Equal(state, loaded)
+
+	require.NoError(RemoveState(statePath))
+	_, err = LoadState(statePath)
+	assert.Equal(ErrNoCluster, err)
+	assert.NoError(RemoveState(statePath))
+}
@@ -14,14 +14,28 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/ghodss/yaml"
 )
 
+var (
+	ErrUnknownProfile = errors.New("unknown run profile")
+)
+
 // Config is the ackdev global configuration. It contains information and default values
 // used by ackdev to manage local repositories, forks, dependencies, controllers...
 type Config struct {
+	// APIVersion is the version of the configuration file format. Files
+	// written with older versions are migrated when they're loaded, see
+	// Migrate.
+	APIVersion string `yaml:"apiVersion" json:"apiVersion"`
 	// RootDirectory is the parent directory of the all ACK local repositories.
 	// If it's not specified ackdev will use $GOPATH/src/github.com/aws-controllers-k8s
 	RootDirectory string `yaml:"rootDirectory" json:"rootDirectory"`
@@ -36,6 +50,11 @@ type Config struct {
 	// RunConfig let specify the arguments and flags used to run a controller locally,
 	// without having to build it image or deploy it into a cluster.
 	RunConfig RunConfig `yaml:"run" json:"run"`
+	// TestConfig contains the options used to run the controllers tests.
+	TestConfig TestConfig `yaml:"test,omitempty" json:"test,omitempty"`
+	// Workspace contains the options used to generate the go.work file of the
+	// root directory.
+	Workspace WorkspaceConfig `yaml:"workspace,omitempty" json:"workspace,omitempty"`
 }
 
 // RepositoriesConfig represent repositories that are be managed by ackdev.
@@ -52,19 +71,144 @@ type GithubConfig struct {
 	// Token is the token used to make Github API calls. This token needs at least
 	// the 'repo' scope. To generate this token please follow instructions in:
 	// https://docs.github.com/en/github/authenticating-to-github/creating-a-personal-access-token
+	// Storing the token in the configuration file is optional, see Credentials.
 	Token string `yaml:"token" json:"token"`
+	// Credentials configures the sources used to resolve the Github token.
+	Credentials CredentialsConfig `yaml:"credentials" json:"credentials"`
 	// Username is the ackdev contributor Github username.
 	Username string `yaml:"username" json:"username"`
 	// ForkPrefix is the prefix prepended to the personal forks of ACK repositories.
 	// For example if ForkPrefix is 'ack-', ackdev will fork code-generator repository
 	// and rename to 'ack-code-generator.
 	ForkPrefix string `yaml:"forkPrefix" json:"forkPrefix"`
+	// APIURL is the Github API base URL. It needs to be set when using Github
+	// Enterprise, e.g https://github.example.com/api/v3/. Defaults to the
+	// public Github API.
+	APIURL string `yaml:"apiURL,omitempty" json:"apiURL,omitempty"`
+	// Host is the git host used to build the repositories remote URLs.
+	// Defaults to github.com
+	Host string `yaml:"host,omitempty" json:"host,omitempty"`
+	// Organization is the organization owning the upstream repositories.
+	// Defaults to aws-controllers-k8s
+	Organization string `yaml:"organization,omitempty" json:"organization,omitempty"`
+	// ForkOrganization is the organization owning the ACK repositories forks.
+	// Defaults to the user account (Username).
+	ForkOrganization string `yaml:"forkOrganization,omitempty" json:"forkOrganization,omitempty"`
+	// ForkTimeout is the maximum duration ackdev waits for a new fork to be
+	// accessible, e.g 90s or 5m. Defaults to 2m.
+	ForkTimeout string `yaml:"forkTimeout,omitempty" json:"forkTimeout,omitempty"`
+}
+
+// GetHost returns the configured git host or the default one.
+func (c *GithubConfig) GetHost() string {
+	if c.Host == "" {
+		return DefaultGithubHost
+	}
+	return c.Host
+}
+
+// GetForkTimeout returns the parsed fork timeout or the default one.
+func (c *GithubConfig) GetForkTimeout() (time.Duration, error) {
+	if c.ForkTimeout == "" {
+		return DefaultForkTimeout, nil
+	}
+	timeout, err := time.ParseDuration(c.ForkTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fork timeout: %v", err)
+	}
+	return timeout, nil
+}
+
+// GetOrganization returns the configured upstream organization or the
+// default one.
+func (c *GithubConfig) GetOrganization() string {
+	if c.Organization == "" {
+		return DefaultUpstreamOrganization
+	}
+	return c.Organization
+}
+
+// GetForkOwner returns the account owning the ACK repositories forks, the fork
+// organization if set or the user account otherwise.
+func (c *GithubConfig) GetForkOwner() string {
+	if c.ForkOrganization != "" {
+		return c.ForkOrganization
+	}
+	return c.Username
+}
+
+const (
+	// GitProtocolHTTPS is the protocol used to clone repositories with HTTPS
+	// and Github token authentication.
+	GitProtocolHTTPS = "https"
+	// GitProtocolSSH is the protocol used to clone repositories with SSH
+	// and SSH key or ssh-agent authentication.
+	GitProtocolSSH = "ssh"
+)
+
+// CredentialsConfig configures where and in which order ackdev looks for the
+// Github token.
+type CredentialsConfig struct {
+	// Sources is the ordered list of sources ackdev tries to resolve the Github
+	// token from. Supported sources are 'env', 'command', 'git-credential' and
+	// 'config'. Defaults to ['config', 'env'].
+	Sources []string `yaml:"sources,omitempty" json:"sources,omitempty"`
+	// EnvVariable is the environment variable read by the 'env' source.
+	// Defaults to GITHUB_TOKEN.
+	EnvVariable string `yaml:"envVariable,omitempty" json:"envVariable,omitempty"`
+	// Command is the command executed by the 'command' source, its output is
+	// used as the token. Defaults to 'gh auth token'.
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
 }
 
 // Git contains information used by ackdev to manage local git repositories.
 type GitConfig struct {
+	// Protocol is the protocol used to clone Github repositories. It can be
+	// either 'https' or 'ssh'. If SSHKeyPath is set, ssh is always used.
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
 	// SSHKeyPath is the full path the SSH key used to clone Github repositories.
+	// If it's not specified and the protocol is 'ssh', ackdev will authenticate
+	// using the ssh-agent listening on $SSH_AUTH_SOCK.
 	SSHKeyPath string `yaml:"sshKeyPath" json:"sshKeyPath"`
+	// KnownHostsPath is the full path of the known_hosts file used to verify
+	// SSH host keys. Defaults to $SSH_KNOWN_HOSTS or ~/.ssh/known_hosts
+	KnownHostsPath string `yaml:"knownHostsPath,omitempty" json:"knownHostsPath,omitempty"`
+}
+
+// UseSSH returns true if the Github repositories should be cloned using
+// the SSH protocol.
+func (c *GitConfig) UseSSH() bool {
+	return c.SSHKeyPath != "" || c.Protocol == GitProtocolSSH
+}
+
+// TestConfig contains the options used to run the controllers tests.
+type TestConfig struct {
+	// ReportDirectory is the directory the JUnit XML reports are written in.
+	// Defaults to DefaultReportDirectory.
+	ReportDirectory string `yaml:"reportDirectory,omitempty" json:"reportDirectory,omitempty"`
+}
+
+// GetReportDirectory returns the reports directory or the default one.
+func (c *TestConfig) GetReportDirectory() string {
+	if c.ReportDirectory == "" {
+		return DefaultReportDirectory
+	}
+	return c.ReportDirectory
+}
+
+// WorkspaceConfig contains the options used to generate the go.work file of the
+// root directory. The workspace contains the runtime and code-generator clones,
+// and the controllers selected by Filter.
+type WorkspaceConfig struct {
+	// Enabled tells whether the workspace is updated when repositories are
+	// cloned.
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+	// Filter is the filter expression selecting the controllers added to the
+	// workspace, e.g "name=s3-controller". All the controllers are added if
+	// empty.
+	Filter string `yaml:"filter,omitempty" json:"filter,omitempty"`
+	// Exclude is the list of repositories never added to the workspace.
+	Exclude []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
 }
 
 // RunConfig contains flags and arguments passed to service controllers binaries when
@@ -73,8 +217,101 @@ type RunConfig struct {
 	// Flags is the map of flags/values passed to the controller binaries. For example
 	// to pass --aws-region=us-west-1 you'll need to set Flags to {"aws-region","us-west-1"}
 	Flags map[string]string `yaml:"flags" json:"flags"`
+	// Services contains the service specific run configurations, indexed by
+	// service name. e.g s3
+	Services map[string]ServiceRunConfig `yaml:"services,omitempty" json:"services,omitempty"`
+	// Profiles contains named run configurations (e.g dev, localstack) layered
+	// over the global and service configurations when selected.
+	Profiles map[string]RunProfile `yaml:"profiles,omitempty" json:"profiles,omitempty"`
+}
+
+// ServiceRunConfig contains the flags and environment variables passed to a
+// single service controller.
+type ServiceRunConfig struct {
+	// Flags is the map of flags/values passed to the service controller binary.
+	// They override the global run flags.
+	Flags map[string]string `yaml:"flags,omitempty" json:"flags,omitempty"`
+	// Env is the map of environment variables set in the service controller
+	// environment.
+	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+}
+
+// RunProfile is a named run configuration.
+type RunProfile struct {
+	// Flags is the map of flags/values passed to the controller binaries. They
+	// override the global and service flags.
+	Flags map[string]string `yaml:"flags,omitempty" json:"flags,omitempty"`
+	// Env is the map of environment variables set in the controllers
+	// environment.
+	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	// KubeContext is the kubeconfig context the controllers connect to.
+	// Defaults to the kubeconfig current context.
+	KubeContext string `yaml:"kubeContext,omitempty" json:"kubeContext,omitempty"`
+	// Services contains the profile service specific run configurations.
+	Services map[string]ServiceRunConfig `yaml:"services,omitempty" json:"services,omitempty"`
+}
+
+// ResolvedRunConfig is the run configuration of a service controller, once
+// all the configuration layers are merged.
+type ResolvedRunConfig struct {
+	// Flags is the map of flags/values passed to the controller binary
+	Flags map[string]string
+	// Env is the map of environment variables set in the controller environment
+	Env map[string]string
+	// KubeContext is the kubeconfig context the controller connects to. Empty
+	// if the current context should be used.
+	KubeContext string
+}
+
+// Resolve merges the run configuration layers of a service controller. From
+// the lowest to the highest priority: the global configuration, the service
+// configuration, the profile configuration and the profile service configuration.
+// An empty profile selects no profile. It returns ErrUnknownProfile if the
+// profile isn't configured.
+func (c *RunConfig) Resolve(service, profile string) (*ResolvedRunConfig, error) {
+	resolved := &ResolvedRunConfig{
+		Flags: map[string]string{},
+		Env:   map[string]string{},
+	}
+	merge := func(layer ServiceRunConfig) {
+		for k, v := range layer.Flags {
+			resolved.Flags[k] = v
+		}
+		for k, v := range layer.Env {
+			resolved.Env[k] = v
+		}
+	}
+
+	merge(ServiceRunConfig{Flags: c.Flags})
+	merge(c.Services[service])
+	if profile == "" {
+		return resolved, nil
+	}
+
+	p, ok := c.Profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProfile, profile)
+	}
+	merge(ServiceRunConfig{Flags: p.Flags, Env: p.Env})
+	merge(p.Services[service])
+	resolved.KubeContext = p.KubeContext
+	return resolved, nil
 }
 
+const (
+	// DefaultGithubHost is the default git host of the ACK repositories
+	DefaultGithubHost = "github.com"
+	// DefaultUpstreamOrganization is the Github organization owning the ACK
+	// repositories
+	DefaultUpstreamOrganization = "aws-controllers-k8s"
+	// DefaultForkTimeout is the default maximum duration ackdev waits for
+	// a new fork to be accessible.
+	DefaultForkTimeout = 2 * time.Minute
+	// DefaultReportDirectory is the default directory test reports are
+	// written in, relative to the working directory.
+	DefaultReportDirectory = "reports"
+)
+
 // DefaultConfig is the default configuration used to generated ackdev config
 var DefaultConfig = Config{
 	Repositories: RepositoriesConfig{
@@ -91,30 +328,120 @@ var DefaultConfig = Config{
 	},
 }
 
-// Load reads a local configuration file and returns an ackdev configuration object.
+// Load resolves and validates the ackdev configuration from the defaults, the
+// local configuration file, if it exists, and the ACKDEV_* environment
+// variables. It returns a ValidationError if the configuration contains errors.
 func Load(configPath string) (*Config, error) {
+	cfg, _, err := LoadWithOptions(configPath, LoadOptions{Environ: os.Environ()})
+	return cfg, err
+}
+
+// Read reads a local configuration file and returns an ackdev configuration
+// object, without validating it or applying environment variables. Files
+// written with an older apiVersion are migrated in memory, the file itself is
+// only rewritten by Save.
+func Read(configPath string) (*Config, error) {
+	cfg, _, err := read(configPath)
+	return cfg, err
+}
+
+// read reads a local configuration file and returns the configuration object
+// and the migrated YAML document.
+func read(configPath string) (*Config, map[string]interface{}, error) {
 	content, err := ioutil.ReadFile(configPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	result, err := Migrate(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot migrate %s: %w", configPath, err)
 	}
 
-	cfg := DefaultConfig
-	err = yaml.Unmarshal(content, &cfg)
+	cfg := DefaultConfig.DeepCopy()
+	err = yaml.Unmarshal(result.Content, cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return &cfg, nil
+	doc := map[string]interface{}{}
+	err = yaml.Unmarshal(result.Content, &doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, doc, nil
+}
+
+// DeepCopy returns a copy of the configuration sharing no list or map with
+// the original one.
+func (c *Config) DeepCopy() *Config {
+	// The configuration only contains JSON serialisable fields, marshalling
+	// it can't fail.
+	b, _ := json.Marshal(c)
+	copied := &Config{}
+	_ = json.Unmarshal(b, copied)
+	return copied
 }
 
-// Save serialise a configuration object and writes it to given filepath.
+// RedactedValue replaces the secret values of a configuration, see Redacted.
+const RedactedValue = "***"
+
+// Redacted returns a copy of the configuration whose secret values, i.e the
+// Github token, are replaced by RedactedValue when they're set.
+func (c *Config) Redacted() *Config {
+	redacted := c.DeepCopy()
+	if redacted.Github.Token != "" {
+		redacted.Github.Token = RedactedValue
+	}
+	return redacted
+}
+
+// Marshal serialises a configuration object with the current apiVersion.
+func Marshal(cfg *Config) ([]byte, error) {
+	c := *cfg
+	c.APIVersion = CurrentAPIVersion
+	return yaml.Marshal(&c)
+}
+
+// Save serialise a configuration object and writes it to given filepath. The file
+// may contain a Github token, so it is only readable by the current user. If the
+// existing file was written with an older apiVersion, it's backed up first, see
+// BackupPath.
 func Save(cfg *Config, filename string) error {
-	bytes, err := yaml.Marshal(cfg)
+	bytes, err := Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	// Write the file the configuration path links to, instead of replacing
+	// the link.
+	if target, err := filepath.EvalSymlinks(filename); err == nil {
+		filename = target
+	}
+	if err := backup(filename); err != nil {
+		return fmt.Errorf("cannot backup %s: %v", filename, err)
+	}
+	return writeFileAtomic(filename, bytes, 0600)
+}
+
+// writeFileAtomic writes data to a temporary file and renames it to filename,
+// so that filename is never left partially written.
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	f, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename)+".tmp-")
 	if err != nil {
-		return nil
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
 	}
-	err = ioutil.WriteFile(filename, bytes, 0777)
 	if err != nil {
 		return err
 	}
-	return nil
+	if err := os.Chmod(f.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filename)
 }
@@ -0,0 +1,132 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunConfig_Resolve(t *testing.T) {
+	runConfig := &RunConfig{
+		Flags: map[string]string{
+			"aws-region": "us-west-2",
+			"log-level":  "info",
+		},
+		Services: map[string]ServiceRunConfig{
+			"rds": {
+				Flags: map[string]string{"aws-region": "eu-west-1"},
+				Env:   map[string]string{"RDS_ENV": "rds"},
+			},
+		},
+		Profiles: map[string]RunProfile{
+			"localstack": {
+				Flags:       map[string]string{"aws-endpoint-url": "http://localhost:4566"},
+				Env:         map[string]string{"AWS_ACCESS_KEY_ID": "test"},
+				KubeContext: "kind-ack",
+				Services: map[string]ServiceRunConfig{
+					"rds": {
+						Flags: map[string]string{"log-level": "debug"},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		service string
+		profile string
+		want    *ResolvedRunConfig
+		wantErr error
+	}{
+		{
+			name:    "global flags",
+			service: "s3",
+			want: &ResolvedRunConfig{
+				Flags: map[string]string{"aws-region": "us-west-2", "log-level": "info"},
+				Env:   map[string]string{},
+			},
+		},
+		{
+			name:    "service flags",
+			service: "rds",
+			want: &ResolvedRunConfig{
+				Flags: map[string]string{"aws-region": "eu-west-1", "log-level": "info"},
+				Env:   map[string]string{"RDS_ENV": "rds"},
+			},
+		},
+		{
+			name:    "profile",
+			service: "s3",
+			profile: "localstack",
+			want: &ResolvedRunConfig{
+				Flags: map[string]string{
+					"aws-region":       "us-west-2",
+					"log-level":        "info",
+					"aws-endpoint-url": "http://localhost:4566",
+				},
+				Env:         map[string]string{"AWS_ACCESS_KEY_ID": "test"},
+				KubeContext: "kind-ack",
+			},
+		},
+		{
+			name:    "profile service flags",
+			service: "rds",
+			profile: "localstack",
+			want: &ResolvedRunConfig{
+				Flags: map[string]string{
+					"aws-region":       "eu-west-1",
+					"log-level":        "debug",
+					"aws-endpoint-url": "http://localhost:4566",
+				},
+				Env:         map[string]string{"RDS_ENV": "rds", "AWS_ACCESS_KEY_ID": "test"},
+				KubeContext: "kind-ack",
+			},
+		},
+		{
+			name:    "unknown profile",
+			service: "s3",
+			profile: "production",
+			wantErr: ErrUnknownProfile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := runConfig.Resolve(tt.service, tt.profile)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resolved)
+		})
+	}
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := &Config{Github: GithubConfig{Token: "ghp_secret", Username: "ack-bot"}}
+	redacted := cfg.Redacted()
+	assert.Equal(RedactedValue, redacted.Github.Token)
+	assert.Equal("ack-bot", redacted.Github.Username)
+	assert.Equal("ghp_secret", cfg.Github.Token)
+
+	// Unset secrets stay empty
+	assert.Empty((&Config{}).Redacted().Github.Token)
+}
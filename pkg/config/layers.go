@@ -0,0 +1,307 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// EnvPrefix is the prefix of the environment variables overriding the
+	// configuration file values, see EnvVariable.
+	EnvPrefix = "ACKDEV_"
+)
+
+var (
+	ErrInvalidOverride = errors.New("invalid configuration override")
+)
+
+// OriginKind is the kind of layer a configuration value comes from. Layers are
+// applied in the following order: default, file, env and flag.
+type OriginKind string
+
+const (
+	// OriginDefault is the origin of the values set by DefaultConfig or left
+	// empty.
+	OriginDefault OriginKind = "default"
+	// OriginFile is the origin of the values read from the configuration file
+	OriginFile OriginKind = "file"
+	// OriginEnv is the origin of the values set by ACKDEV_* environment
+	// variables
+	OriginEnv OriginKind = "env"
+	// OriginFlag is the origin of the values set by command line flags
+	OriginFlag OriginKind = "flag"
+)
+
+// Origin tells where the effective value of a configuration field comes from.
+type Origin struct {
+	Kind OriginKind `json:"kind"`
+	// Source is the configuration file path, the environment variable or the
+	// flag that set the value. It's empty for default values.
+	Source string `json:"source,omitempty"`
+}
+
+// String returns the origin formatted as kind:source, e.g
+// env:ACKDEV_GITHUB_TOKEN
+func (o Origin) String() string {
+	if o.Source == "" {
+		return string(o.Kind)
+	}
+	return fmt.Sprintf("%s:%s", o.Kind, o.Source)
+}
+
+// Origins maps the dotted paths of the configuration values, see Get, to their
+// origin. A path missing from Origins has the origin of its closest parent, or
+// OriginDefault.
+type Origins map[string]Origin
+
+// Lookup returns the origin of the value designated by a dotted path.
+func (o Origins) Lookup(path string) Origin {
+	segments, err := splitPath(path)
+	if err != nil {
+		return Origin{Kind: OriginDefault}
+	}
+	for i := len(segments); i > 0; i-- {
+		if origin, ok := o[formatPath(segments[:i])]; ok {
+			return origin
+		}
+	}
+	return Origin{Kind: OriginDefault}
+}
+
+// set records the origin of a path, replacing the origins of its children.
+func (o Origins) set(path string, origin Origin) {
+	for p := range o {
+		if strings.HasPrefix(p, path+".") {
+			delete(o, p)
+		}
+	}
+	o[path] = origin
+}
+
+// LoadOptions contains the layers applied on top of the configuration file.
+type LoadOptions struct {
+	// Environ is the list of environment variables, formatted as KEY=VALUE,
+	// e.g os.Environ(). Only the ACKDEV_* variables are used.
+	Environ []string
+	// Overrides is the list of path=value overrides set with command line
+	// flags, e.g github.forkPrefix=ack-. They're parsed like Set values.
+	Overrides []string
+	// OverridesFlag is the flag the overrides were set with, e.g
+	// --config-override. It's only used as their origin.
+	OverridesFlag string
+}
+
+// Resolve returns the ackdev configuration resolved from the defaults, the
+// configuration file, the environment variables and the overrides, along with
+// the origin of each value. The configuration file is optional and the result
+// isn't validated.
+func Resolve(configPath string, opts LoadOptions) (*Config, Origins, error) {
+	origins := Origins{}
+	cfg, doc, err := read(configPath)
+	switch {
+	case os.IsNotExist(err):
+		cfg = DefaultConfig.DeepCopy()
+	case err != nil:
+		return nil, nil, err
+	default:
+		for _, path := range documentPaths(doc, "") {
+			if path != apiVersionKey {
+				origins[path] = Origin{Kind: OriginFile, Source: configPath}
+			}
+		}
+	}
+	cfg.APIVersion = CurrentAPIVersion
+
+	if err := ApplyLayers(cfg, origins, opts); err != nil {
+		return nil, nil, err
+	}
+	return cfg, origins, nil
+}
+
+// LoadWithOptions resolves the ackdev configuration, see Resolve, and
+// validates it. It returns a ValidationError if the configuration contains
+// errors.
+func LoadWithOptions(configPath string, opts LoadOptions) (*Config, Origins, error) {
+	cfg, origins, err := Resolve(configPath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		if _, statErr := os.Stat(configPath); os.IsNotExist(statErr) {
+			return nil, nil, fmt.Errorf("%s doesn't exist, run 'ackdev setup' or set %s* environment variables: %w", configPath, EnvPrefix, err)
+		}
+		return nil, nil, err
+	}
+	return cfg, origins, nil
+}
+
+// ApplyLayers applies the environment variables, then the overrides, to a
+// configuration and records their origins.
+func ApplyLayers(cfg *Config, origins Origins, opts LoadOptions) error {
+	env := map[string]string{}
+	for _, kv := range opts.Environ {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) == 2 && strings.HasPrefix(parts[0], EnvPrefix) {
+			env[parts[0]] = parts[1]
+		}
+	}
+	for _, path := range Paths() {
+		name := EnvVariable(path)
+		value, ok := env[name]
+		if !ok {
+			continue
+		}
+		if err := Set(cfg, path, value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		origins.set(path, Origin{Kind: OriginEnv, Source: name})
+	}
+
+	for _, override := range opts.Overrides {
+		parts := strings.SplitN(override, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("%w %s, expected path=value", ErrInvalidOverride, override)
+		}
+		if err := Set(cfg, parts[0], parts[1]); err != nil {
+			return fmt.Errorf("%s %s: %w", opts.OverridesFlag, override, err)
+		}
+		// Set succeeded, the path is valid
+		segments, _ := splitPath(parts[0])
+		origins.set(formatPath(segments), Origin{Kind: OriginFlag, Source: opts.OverridesFlag})
+	}
+	return nil
+}
+
+// Paths returns the dotted paths of the configuration fields that can be set
+// with environment variables, in declaration order. Structs are walked, maps
+// and lists are set as a whole.
+func Paths() []string {
+	paths := []string{}
+	var walk func(t reflect.Type, prefix string)
+	walk = func(t reflect.Type, prefix string) {
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			if name == "" || name == "-" || (prefix == "" && name == apiVersionKey) {
+				continue
+			}
+			path := prefix + name
+			if t.Field(i).Type.Kind() == reflect.Struct {
+				walk(t.Field(i).Type, path+".")
+				continue
+			}
+			paths = append(paths, path)
+		}
+	}
+	walk(reflect.TypeOf(Config{}), "")
+	return paths
+}
+
+// EnvVariable returns the environment variable overriding the configuration
+// field designated by a dotted path, e.g ACKDEV_GITHUB_FORK_PREFIX for
+// github.forkPrefix.
+func EnvVariable(path string) string {
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		segments[i] = screamingSnakeCase(segment)
+	}
+	return EnvPrefix + strings.Join(segments, "_")
+}
+
+// screamingSnakeCase converts a camel case name to upper case words separated
+// by underscores, e.g apiURL becomes API_URL.
+func screamingSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// documentPaths returns the dotted paths of the leaf values of a YAML
+// document. Lists are leaf values, empty maps don't set any value.
+func documentPaths(doc map[string]interface{}, prefix string) []string {
+	paths := []string{}
+	for key, value := range doc {
+		path := joinPath(prefix, key)
+		if m, ok := value.(map[string]interface{}); ok {
+			paths = append(paths, documentPaths(m, path)...)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// Value is an effective configuration value and its origin.
+type Value struct {
+	Path   string      `json:"path"`
+	Value  interface{} `json:"value"`
+	Origin Origin      `json:"origin"`
+}
+
+// Values returns the leaf values of a configuration with their origin, sorted
+// by path. Maps are walked, so that each entry has its own origin. Secret
+// values are redacted, see Redacted.
+func Values(cfg *Config, origins Origins) []*Value {
+	cfg = cfg.Redacted()
+	values := []*Value{}
+	var walk func(v reflect.Value, path string)
+	walk = func(v reflect.Value, path string) {
+		switch {
+		case v.Kind() == reflect.Struct:
+			t := v.Type()
+			for i := 0; i < t.NumField(); i++ {
+				name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+				if name == "" || name == "-" || (path == "" && name == apiVersionKey) {
+					continue
+				}
+				walk(v.Field(i), joinPath(path, name))
+			}
+		case v.Kind() == reflect.Map && v.Len() > 0:
+			for _, key := range v.MapKeys() {
+				walk(v.MapIndex(key), joinPath(path, key.String()))
+			}
+		default:
+			values = append(values, &Value{Path: path, Value: v.Interface(), Origin: origins.Lookup(path)})
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem(), "")
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Path < values[j].Path
+	})
+	return values
+}
+
+// joinPath appends a segment to a dotted path, quoting it if it contains dots.
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return quoteSegment(name)
+	}
+	return prefix + "." + quoteSegment(name)
+}
@@ -0,0 +1,190 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvVariable(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "rootDirectory", want: "ACKDEV_ROOT_DIRECTORY"},
+		{path: "github.token", want: "ACKDEV_GITHUB_TOKEN"},
+		{path: "github.forkPrefix", want: "ACKDEV_GITHUB_FORK_PREFIX"},
+		{path: "github.apiURL", want: "ACKDEV_GITHUB_API_URL"},
+		{path: "git.sshKeyPath", want: "ACKDEV_GIT_SSH_KEY_PATH"},
+		{path: "github.credentials.envVariable", want: "ACKDEV_GITHUB_CREDENTIALS_ENV_VARIABLE"},
+		{path: "run.flags", want: "ACKDEV_RUN_FLAGS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, EnvVariable(tt.path))
+		})
+	}
+}
+
+func TestPaths(t *testing.T) {
+	paths := Paths()
+	assert.Contains(t, paths, "rootDirectory")
+	assert.Contains(t, paths, "github.credentials.sources")
+	assert.Contains(t, paths, "run.flags")
+	assert.NotContains(t, paths, "apiVersion")
+	assert.NotContains(t, paths, "github")
+
+	// Each path has its own environment variable
+	seen := map[string]string{}
+	for _, path := range paths {
+		name := EnvVariable(path)
+		assert.NotContains(t, seen, name, "%s and %s share %s", path, seen[name], name)
+		seen[name] = path
+	}
+}
+
+func TestResolve(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `apiVersion: v1alpha2
+rootDirectory: /file
+github:
+  username: file-user
+  credentials: {}
+repositories:
+  services: [s3]
+run:
+  flags:
+    aws-region: us-west-2
+    log-level: info
+    leader.election-id: s3
+    leader.election-namespace: ack-system
+`
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(content), 0600))
+
+	cfg, origins, err := Resolve(configPath, LoadOptions{
+		Environ: []string{
+			"ACKDEV_ROOT_DIRECTORY=/env",
+			"ACKDEV_GITHUB_TOKEN=token",
+			"ACKDEV_REPOSITORIES_SERVICES=s3,ecr",
+			"ACKDEV_UNKNOWN=ignored",
+			"GITHUB_TOKEN=ignored",
+		},
+		Overrides:     []string{"rootDirectory=/flag", "run.flags.log-level=debug", `run.flags."leader.election-id"=ecr`},
+		OverridesFlag: "--config-override",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/flag", cfg.RootDirectory)
+	assert.Equal(t, "token", cfg.Github.Token)
+	assert.Equal(t, "file-user", cfg.Github.Username)
+	assert.Equal(t, "ack-", cfg.Github.ForkPrefix)
+	assert.Equal(t, []string{"s3", "ecr"}, cfg.Repositories.Services)
+	assert.Equal(t, map[string]string{
+		"aws-region":                "us-west-2",
+		"log-level":                 "debug",
+		"leader.election-id":        "ecr",
+		"leader.election-namespace": "ack-system",
+	}, cfg.RunConfig.Flags)
+
+	file := Origin{Kind: OriginFile, Source: configPath}
+	flag := Origin{Kind: OriginFlag, Source: "--config-override"}
+	tests := []struct {
+		path string
+		want Origin
+	}{
+		{path: "rootDirectory", want: flag},
+		{path: "github.token", want: Origin{Kind: OriginEnv, Source: "ACKDEV_GITHUB_TOKEN"}},
+		{path: "github.username", want: file},
+		{path: "github.forkPrefix", want: Origin{Kind: OriginDefault}},
+		{path: "github.credentials.sources", want: Origin{Kind: OriginDefault}},
+		{path: "repositories.services", want: Origin{Kind: OriginEnv, Source: "ACKDEV_REPOSITORIES_SERVICES"}},
+		{path: "run.flags.aws-region", want: file},
+		{path: "run.flags.log-level", want: flag},
+		{path: `run.flags."leader.election-id"`, want: flag},
+		{path: `run.flags."leader.election-namespace"`, want: file},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, origins.Lookup(tt.path))
+		})
+	}
+
+	values := Values(cfg, origins)
+	paths := []string{}
+	for _, v := range values {
+		paths = append(paths, v.Path)
+		switch v.Path {
+		case "run.flags.log-level":
+			assert.Equal(t, "debug", v.Value)
+			assert.Equal(t, flag, v.Origin)
+		case "github.token":
+			assert.Equal(t, RedactedValue, v.Value)
+			assert.Equal(t, Origin{Kind: OriginEnv, Source: "ACKDEV_GITHUB_TOKEN"}, v.Origin)
+		}
+	}
+	// The configuration itself isn't redacted
+	assert.Equal(t, "token", cfg.Github.Token)
+	assert.Contains(t, paths, "run.flags.aws-region")
+	assert.Contains(t, paths, `run.flags."leader.election-id"`)
+	assert.Contains(t, paths, "run.services")
+	assert.IsIncreasing(t, paths)
+}
+
+func TestResolve_MissingFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	rootDirectory := t.TempDir()
+
+	cfg, origins, err := LoadWithOptions(configPath, LoadOptions{
+		Environ: []string{"ACKDEV_ROOT_DIRECTORY=" + rootDirectory},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, rootDirectory, cfg.RootDirectory)
+	assert.Equal(t, DefaultConfig.Repositories.Core, cfg.Repositories.Core)
+	assert.Equal(t, Origin{Kind: OriginDefault}, origins.Lookup("repositories.core"))
+}
+
+func TestResolve_Errors(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	_, _, err := Resolve(configPath, LoadOptions{Environ: []string{"ACKDEV_WORKSPACE_ENABLED=maybe"}})
+	assert.True(t, errors.Is(err, ErrInvalidValue), "got error %v", err)
+	assert.Contains(t, err.Error(), "ACKDEV_WORKSPACE_ENABLED")
+
+	_, _, err = Resolve(configPath, LoadOptions{Overrides: []string{"rootDirectory"}})
+	assert.True(t, errors.Is(err, ErrInvalidOverride), "got error %v", err)
+
+	_, _, err = Resolve(configPath, LoadOptions{Overrides: []string{"github.unknown=x"}})
+	assert.True(t, errors.Is(err, ErrUnknownPath), "got error %v", err)
+}
+
+func TestOrigins_Set(t *testing.T) {
+	file := Origin{Kind: OriginFile, Source: "config.yaml"}
+	env := Origin{Kind: OriginEnv, Source: "ACKDEV_RUN_FLAGS"}
+	origins := Origins{
+		"run.flags.aws-region": file,
+		"run.flagsPrefix":      file,
+	}
+	origins.set("run.flags", env)
+
+	// The environment variable replaces the whole map
+	assert.Equal(t, env, origins.Lookup("run.flags.aws-region"))
+	assert.Equal(t, file, origins.Lookup("run.flagsPrefix"))
+	assert.Equal(t, Origin{Kind: OriginDefault}, origins.Lookup("run.services"))
+}
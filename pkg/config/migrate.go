@@ -0,0 +1,257 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// APIVersionV1Alpha1 is the version of the configuration files written
+	// before apiVersion was introduced. Fields missing from these files were
+	// implicitly set to their DefaultConfig values.
+	APIVersionV1Alpha1 = "v1alpha1"
+	// APIVersionV1Alpha2 pins the defaults that v1alpha1 files relied on and
+	// stores run flag names without their leading dashes.
+	APIVersionV1Alpha2 = "v1alpha2"
+
+	// CurrentAPIVersion is the version of the configuration files written by
+	// this version of ackdev.
+	CurrentAPIVersion = APIVersionV1Alpha2
+
+	// apiVersionKey is the configuration file key holding the apiVersion
+	apiVersionKey = "apiVersion"
+)
+
+var (
+	ErrUnsupportedAPIVersion = errors.New("unsupported configuration apiVersion")
+)
+
+// migration upgrades a configuration document from one apiVersion to the next
+// one.
+type migration struct {
+	from        string
+	to          string
+	description string
+	migrate     func(doc map[string]interface{})
+}
+
+// migrations is the ordered list of migrations applied to upgrade old
+// configuration files. Each one upgrades the version produced by the previous
+// one.
+var migrations = []migration{
+	{
+		from:        APIVersionV1Alpha1,
+		to:          APIVersionV1Alpha2,
+		description: "pin github.forkPrefix and repositories.core defaults, remove leading dashes from run flag names",
+		migrate:     migrateV1Alpha1ToV1Alpha2,
+	},
+}
+
+// MigrationResult is the outcome of migrating a configuration file.
+type MigrationResult struct {
+	// From is the apiVersion of the original configuration
+	From string
+	// To is the apiVersion of the migrated configuration
+	To string
+	// Steps describes the migrations applied, in order
+	Steps []string
+	// Content is the migrated configuration. It's the original content if no
+	// migration was applied.
+	Content []byte
+}
+
+// Migrated returns true if at least one migration was applied.
+func (r *MigrationResult) Migrated() bool {
+	return len(r.Steps) > 0
+}
+
+// Migrate upgrades the content of a configuration file to CurrentAPIVersion,
+// one apiVersion at a time. Files without apiVersion are v1alpha1 files.
+func Migrate(content []byte) (*MigrationResult, error) {
+	return migrate(content, migrations, CurrentAPIVersion)
+}
+
+func migrate(content []byte, migrations []migration, target string) (*MigrationResult, error) {
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		// The file is empty
+		doc = map[string]interface{}{}
+	}
+	version, err := documentAPIVersion(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrationResult{From: version, To: version, Content: content}
+	for version != target {
+		step, ok := findMigration(migrations, version)
+		if !ok {
+			return nil, fmt.Errorf("%w %s, the latest supported version is %s", ErrUnsupportedAPIVersion, version, target)
+		}
+		step.migrate(doc)
+		doc[apiVersionKey] = step.to
+		version = step.to
+		result.Steps = append(result.Steps, fmt.Sprintf("%s -> %s: %s", step.from, step.to, step.description))
+	}
+	if !result.Migrated() {
+		return result, nil
+	}
+
+	result.To = version
+	result.Content, err = yaml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func findMigration(migrations []migration, from string) (migration, bool) {
+	for _, m := range migrations {
+		if m.from == from {
+			return m, true
+		}
+	}
+	return migration{}, false
+}
+
+// documentAPIVersion returns the apiVersion of a configuration document.
+func documentAPIVersion(doc map[string]interface{}) (string, error) {
+	value, ok := doc[apiVersionKey]
+	if !ok || value == nil {
+		return APIVersionV1Alpha1, nil
+	}
+	version, ok := value.(string)
+	if !ok || version == "" {
+		return "", fmt.Errorf("%w %v", ErrUnsupportedAPIVersion, value)
+	}
+	return version, nil
+}
+
+// migrateV1Alpha1ToV1Alpha2 writes the defaults v1alpha1 files got from
+// DefaultConfig when the fields were missing, so that changing DefaultConfig
+// doesn't silently change existing configurations. It also removes the leading
+// dashes of run flag names, which were passed twice to the controllers.
+func migrateV1Alpha1ToV1Alpha2(doc map[string]interface{}) {
+	github := childMap(doc, "github")
+	if _, ok := github["forkPrefix"]; !ok {
+		github["forkPrefix"] = DefaultConfig.Github.ForkPrefix
+	}
+	repositories := childMap(doc, "repositories")
+	if _, ok := repositories["core"]; !ok {
+		core := make([]interface{}, 0, len(DefaultConfig.Repositories.Core))
+		for _, name := range DefaultConfig.Repositories.Core {
+			core = append(core, name)
+		}
+		repositories["core"] = core
+	}
+
+	run, ok := doc["run"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	trimFlagDashes := func(layer interface{}) {
+		m, ok := layer.(map[string]interface{})
+		if !ok {
+			return
+		}
+		flags, ok := m["flags"].(map[string]interface{})
+		if !ok {
+			return
+		}
+		for name, value := range flags {
+			trimmed := strings.TrimLeft(name, "-")
+			if trimmed == name {
+				continue
+			}
+			delete(flags, name)
+			// Don't override a flag also set without dashes
+			if _, ok := flags[trimmed]; !ok {
+				flags[trimmed] = value
+			}
+		}
+	}
+	trimServicesFlagDashes := func(layer interface{}) {
+		m, ok := layer.(map[string]interface{})
+		if !ok {
+			return
+		}
+		services, _ := m["services"].(map[string]interface{})
+		for _, service := range services {
+			trimFlagDashes(service)
+		}
+	}
+
+	trimFlagDashes(run)
+	trimServicesFlagDashes(run)
+	profiles, _ := run["profiles"].(map[string]interface{})
+	for _, profile := range profiles {
+		trimFlagDashes(profile)
+		trimServicesFlagDashes(profile)
+	}
+}
+
+// childMap returns the map stored at key in m, creating it if it's missing.
+func childMap(m map[string]interface{}, key string) map[string]interface{} {
+	child, ok := m[key].(map[string]interface{})
+	if !ok {
+		child = map[string]interface{}{}
+		m[key] = child
+	}
+	return child
+}
+
+// BackupPath returns the path of the backup of a configuration file written
+// with the given apiVersion.
+func BackupPath(configPath, apiVersion string) string {
+	return fmt.Sprintf("%s.%s.bak", configPath, apiVersion)
+}
+
+// backup copies a configuration file written with an older apiVersion before
+// it's rewritten. Existing backups are kept, they contain the original file.
+func backup(configPath string) error {
+	content, err := ioutil.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	doc := map[string]interface{}{}
+	version := "unknown"
+	if err := yaml.Unmarshal(content, &doc); err == nil {
+		if v, err := documentAPIVersion(doc); err == nil {
+			version = v
+		}
+	}
+	if version == CurrentAPIVersion {
+		return nil
+	}
+
+	backupPath := BackupPath(configPath, version)
+	if _, err := os.Stat(backupPath); err == nil {
+		return nil
+	}
+	return ioutil.WriteFile(backupPath, content, 0600)
+}
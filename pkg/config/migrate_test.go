@@ -0,0 +1,197 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantSteps int
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "empty file",
+			content:   "",
+			wantSteps: 1,
+			want: `apiVersion: v1alpha2
+github:
+  forkPrefix: ack-
+repositories:
+  core:
+  - runtime
+  - dev-tools
+  - community
+  - code-generator
+  - test-infra
+`,
+		},
+		{
+			name: "v1alpha1 file",
+			content: `rootDirectory: /ack
+github:
+  forkPrefix: ""
+repositories:
+  core: [runtime]
+run:
+  flags:
+    --aws-region: us-west-2
+    -log-level: debug
+    log-level: info
+  profiles:
+    dev:
+      flags:
+        --enable-development-logging: "true"
+      services:
+        s3:
+          flags:
+            --aws-region: eu-west-1
+`,
+			wantSteps: 1,
+			want: `apiVersion: v1alpha2
+github:
+  forkPrefix: ""
+repositories:
+  core:
+  - runtime
+rootDirectory: /ack
+run:
+  flags:
+    aws-region: us-west-2
+    log-level: info
+  profiles:
+    dev:
+      flags:
+        enable-development-logging: "true"
+      services:
+        s3:
+          flags:
+            aws-region: eu-west-1
+`,
+		},
+		{
+			name:    "current file",
+			content: "apiVersion: v1alpha2\nrun:\n  flags:\n    --aws-region: us-west-2\n",
+			want:    "apiVersion: v1alpha2\nrun:\n  flags:\n    --aws-region: us-west-2\n",
+		},
+		{
+			name:    "newer file",
+			content: "apiVersion: v1beta1\n",
+			wantErr: ErrUnsupportedAPIVersion,
+		},
+		{
+			name:    "invalid apiVersion",
+			content: "apiVersion: 2\n",
+			wantErr: ErrUnsupportedAPIVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Migrate([]byte(tt.content))
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, result.Steps, tt.wantSteps)
+			assert.Equal(t, CurrentAPIVersion, result.To)
+			assert.Equal(t, tt.want, string(result.Content))
+		})
+	}
+}
+
+func TestMigrate_StepByStep(t *testing.T) {
+	setKey := func(key string) func(map[string]interface{}) {
+		return func(doc map[string]interface{}) {
+			doc[key] = doc[apiVersionKey]
+		}
+	}
+	steps := []migration{
+		{from: "v2", to: "v3", description: "set c", migrate: setKey("c")},
+		{from: "v1", to: "v2", description: "set b", migrate: setKey("b")},
+	}
+
+	result, err := migrate([]byte("apiVersion: v1\n"), steps, "v3")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", result.From)
+	assert.Equal(t, "v3", result.To)
+	assert.Equal(t, []string{"v1 -> v2: set b", "v2 -> v3: set c"}, result.Steps)
+	// Each migration sees the document produced by the previous one
+	assert.Equal(t, "apiVersion: v3\nb: v1\nc: v2\n", string(result.Content))
+
+	result, err = migrate([]byte("apiVersion: v2\n"), steps, "v3")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v2 -> v3: set c"}, result.Steps)
+}
+
+func TestRead_Migrates(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := []byte("rootDirectory: /ack\nrun:\n  flags:\n    --aws-region: us-west-2\n")
+	require.NoError(t, ioutil.WriteFile(configPath, content, 0600))
+
+	cfg, err := Read(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, CurrentAPIVersion, cfg.APIVersion)
+	assert.Equal(t, map[string]string{"aws-region": "us-west-2"}, cfg.RunConfig.Flags)
+
+	// The file is only rewritten by Save
+	got, err := ioutil.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+}
+
+func TestSave_Backup(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	backupPath := BackupPath(configPath, APIVersionV1Alpha1)
+	original := []byte("rootDirectory: /ack\n")
+	require.NoError(t, ioutil.WriteFile(configPath, original, 0600))
+
+	cfg, err := Read(configPath)
+	require.NoError(t, err)
+	require.NoError(t, Save(cfg, configPath))
+
+	got, err := ioutil.ReadFile(backupPath)
+	require.NoError(t, err)
+	assert.Equal(t, original, got)
+
+	saved := map[string]interface{}{}
+	content, err := ioutil.ReadFile(configPath)
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(content, &saved))
+	assert.Equal(t, CurrentAPIVersion, saved[apiVersionKey])
+
+	// Saving an up to date file doesn't back it up, and existing backups are
+	// kept.
+	require.NoError(t, ioutil.WriteFile(configPath, []byte("rootDirectory: /other\n"), 0600))
+	require.NoError(t, Save(cfg, configPath))
+	got, err = ioutil.ReadFile(backupPath)
+	require.NoError(t, err)
+	assert.Equal(t, original, got)
+
+	require.NoError(t, Save(cfg, configPath))
+	matches, err := filepath.Glob(configPath + ".*.bak")
+	require.NoError(t, err)
+	assert.Equal(t, []string{backupPath}, matches)
+}
@@ -0,0 +1,274 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownPath  = errors.New("unknown configuration path")
+	ErrInvalidValue = errors.New("invalid configuration value")
+	ErrPathNotSet   = errors.New("configuration path not set")
+)
+
+// Get returns the value of the configuration field designated by a dotted
+// path made of the fields json names and map keys, e.g github.forkPrefix or
+// run.flags.aws-region. Map keys containing dots are double quoted, e.g
+// run.flags."leader.election-id"; keys containing double quotes can't be
+// designated. An empty path designates the whole configuration.
+func Get(cfg *Config, path string) (interface{}, error) {
+	v := reflect.ValueOf(cfg).Elem()
+	segments, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, segment := range segments {
+		switch v.Kind() {
+		case reflect.Struct:
+			field, ok := structField(v, segment)
+			if !ok {
+				return nil, unknownPathError(segments[:i+1])
+			}
+			v = field
+		case reflect.Map:
+			value := v.MapIndex(reflect.ValueOf(segment))
+			if !value.IsValid() {
+				return nil, fmt.Errorf("%w: %s", ErrPathNotSet, formatPath(segments[:i+1]))
+			}
+			v = value
+		default:
+			return nil, unknownPathError(segments[:i+1])
+		}
+	}
+	return v.Interface(), nil
+}
+
+// Set parses value according to the type of the configuration field
+// designated by path, see Get, and assigns it. Lists are comma separated, e.g
+// s3,ecr, and maps are comma separated key=value pairs, e.g
+// aws-region=us-west-2,log-level=debug. Lists, maps and structs can also be set
+// with a JSON value. Missing map entries are created.
+func Set(cfg *Config, path string, value string) error {
+	segments, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		return fmt.Errorf("%w: path is empty", ErrUnknownPath)
+	}
+	return update(reflect.ValueOf(cfg).Elem(), segments, 0, func(v reflect.Value) error {
+		parsed, err := parseValue(v.Type(), value)
+		if err != nil {
+			return fmt.Errorf("%w for %s: %v", ErrInvalidValue, path, err)
+		}
+		v.Set(parsed)
+		return nil
+	}, true)
+}
+
+// Unset resets the configuration field designated by path, see Get, to its
+// DefaultConfig value, or to its zero value if it has no default. Map entries
+// without default are removed.
+func Unset(cfg *Config, path string) error {
+	segments, err := splitPath(path)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		return fmt.Errorf("%w: path is empty", ErrUnknownPath)
+	}
+	if def, err := Get(DefaultConfig.DeepCopy(), path); err == nil {
+		return update(reflect.ValueOf(cfg).Elem(), segments, 0, func(v reflect.Value) error {
+			v.Set(reflect.ValueOf(def))
+			return nil
+		}, true)
+	}
+	return update(reflect.ValueOf(cfg).Elem(), segments, 0, func(v reflect.Value) error {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}, false)
+}
+
+// update walks segments from v and calls fn with the designated value. Map
+// values aren't addressable, so they're copied, updated and stored back. If the
+// last segment is a map key and create is false, the entry is deleted instead.
+func update(v reflect.Value, segments []string, i int, fn func(reflect.Value) error, create bool) error {
+	if i == len(segments) {
+		return fn(v)
+	}
+	segment := segments[i]
+	switch v.Kind() {
+	case reflect.Struct:
+		field, ok := structField(v, segment)
+		if !ok {
+			return unknownPathError(segments[:i+1])
+		}
+		return update(field, segments, i+1, fn, create)
+	case reflect.Map:
+		key := reflect.ValueOf(segment)
+		current := v.MapIndex(key)
+		if !create {
+			if !current.IsValid() {
+				return fmt.Errorf("%w: %s", ErrPathNotSet, formatPath(segments[:i+1]))
+			}
+			if i == len(segments)-1 {
+				v.SetMapIndex(key, reflect.Value{})
+				return nil
+			}
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if current.IsValid() {
+			elem.Set(current)
+		}
+		if err := update(elem, segments, i+1, fn, create); err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	default:
+		return unknownPathError(segments[:i+1])
+	}
+}
+
+// structField returns the field of a struct value whose json name is name.
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if tag == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// parseValue parses s as a value of type t.
+func parseValue(t reflect.Type, s string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return v, fmt.Errorf("expected true or false, got %s", s)
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		if strings.HasPrefix(strings.TrimSpace(s), "[") {
+			return v, json.Unmarshal([]byte(s), v.Addr().Interface())
+		}
+		if t.Elem().Kind() != reflect.String {
+			return v, fmt.Errorf("expected a JSON list")
+		}
+		v.Set(reflect.MakeSlice(t, 0, 0))
+		for _, item := range splitList(s) {
+			v.Set(reflect.Append(v, reflect.ValueOf(item).Convert(t.Elem())))
+		}
+	case reflect.Map:
+		if strings.HasPrefix(strings.TrimSpace(s), "{") {
+			return v, json.Unmarshal([]byte(s), v.Addr().Interface())
+		}
+		if t.Elem().Kind() != reflect.String {
+			return v, fmt.Errorf("expected a JSON object")
+		}
+		v.Set(reflect.MakeMap(t))
+		for _, item := range splitList(s) {
+			parts := strings.SplitN(item, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return v, fmt.Errorf("expected key=value pairs, got %s", item)
+			}
+			v.SetMapIndex(reflect.ValueOf(parts[0]), reflect.ValueOf(parts[1]))
+		}
+	case reflect.Struct:
+		return v, json.Unmarshal([]byte(s), v.Addr().Interface())
+	default:
+		return v, fmt.Errorf("unsupported type %s", t)
+	}
+	return v, nil
+}
+
+// splitList splits a comma separated list, ignoring empty items.
+func splitList(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// splitPath splits a dotted path into segments, see Get.
+func splitPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	segments := []string{}
+	rest := path
+	for {
+		var segment string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`) + 1
+			if end == 0 {
+				return nil, fmt.Errorf("%w %s: unterminated quote", ErrUnknownPath, path)
+			}
+			segment, rest = rest[1:end], rest[end+1:]
+			if rest != "" && !strings.HasPrefix(rest, ".") {
+				return nil, fmt.Errorf("%w %s: expected a dot after a quoted key", ErrUnknownPath, path)
+			}
+		} else if i := strings.Index(rest, "."); i >= 0 {
+			segment, rest = rest[:i], rest[i:]
+		} else {
+			segment, rest = rest, ""
+		}
+		segments = append(segments, segment)
+		if rest == "" {
+			return segments, nil
+		}
+		// Skip the dot
+		rest = rest[1:]
+	}
+}
+
+// formatPath joins path segments, quoting the ones containing dots.
+func formatPath(segments []string) string {
+	quoted := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		quoted = append(quoted, quoteSegment(segment))
+	}
+	return strings.Join(quoted, ".")
+}
+
+// quoteSegment double quotes a path segment if it contains dots.
+func quoteSegment(segment string) string {
+	if strings.Contains(segment, ".") {
+		return `"` + segment + `"`
+	}
+	return segment
+}
+
+func unknownPathError(segments []string) error {
+	return fmt.Errorf("%w %s", ErrUnknownPath, formatPath(segments))
+}
@@ -0,0 +1,298 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPathTestConfig() *Config {
+	return &Config{
+		RootDirectory: "/ack",
+		Github:        GithubConfig{ForkPrefix: "me-"},
+		Repositories:  RepositoriesConfig{Services: []string{"s3"}},
+		RunConfig: RunConfig{
+			Flags: map[string]string{"aws-region": "us-west-2", "leader.election-id": "s3"},
+			Services: map[string]ServiceRunConfig{
+				"s3": {Flags: map[string]string{"log-level": "debug"}},
+			},
+		},
+	}
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    interface{}
+		wantErr error
+	}{
+		{path: "rootDirectory", want: "/ack"},
+		{path: "github.forkPrefix", want: "me-"},
+		{path: "repositories.services", want: []string{"s3"}},
+		{path: "run.flags.aws-region", want: "us-west-2"},
+		{path: `run.flags."leader.election-id"`, want: "s3"},
+		{path: `run.flags."aws-region"`, want: "us-west-2"},
+		{path: "run.services.s3", want: ServiceRunConfig{Flags: map[string]string{"log-level": "debug"}}},
+		{path: "run.services.s3.flags.log-level", want: "debug"},
+		{path: "workspace.enabled", want: false},
+		{path: "github.unknown", wantErr: ErrUnknownPath},
+		{path: "rootDirectory.child", wantErr: ErrUnknownPath},
+		{path: "run.flags.log-level", wantErr: ErrPathNotSet},
+		{path: "run.flags.leader.election-id", wantErr: ErrPathNotSet},
+		{path: `run.flags."leader.election-id`, wantErr: ErrUnknownPath},
+		{path: `run."flags"x`, wantErr: ErrUnknownPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := Get(newPathTestConfig(), tt.path)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	cfg := newPathTestConfig()
+	got, err := Get(cfg, "")
+	require.NoError(t, err)
+	assert.Equal(t, *cfg, got)
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		value   string
+		want    func(c *Config)
+		wantErr error
+	}{
+		{
+			name:  "string",
+			path:  "github.forkPrefix",
+			value: "my-",
+			want:  func(c *Config) { c.Github.ForkPrefix = "my-" },
+		},
+		{
+			name:  "bool",
+			path:  "workspace.enabled",
+			value: "true",
+			want:  func(c *Config) { c.Workspace.Enabled = true },
+		},
+		{
+			name:    "invalid bool",
+			path:    "workspace.enabled",
+			value:   "yes",
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name:  "comma separated list",
+			path:  "repositories.services",
+			value: "s3, ecr,,sqs",
+			want:  func(c *Config) { c.Repositories.Services = []string{"s3", "ecr", "sqs"} },
+		},
+		{
+			name:  "JSON list",
+			path:  "repositories.core",
+			value: `["runtime","code-generator"]`,
+			want:  func(c *Config) { c.Repositories.Core = []string{"runtime", "code-generator"} },
+		},
+		{
+			name:  "key=value map",
+			path:  "run.flags",
+			value: "log-level=info,aws-endpoint-url=http://localhost:4566",
+			want: func(c *Config) {
+				c.RunConfig.Flags = map[string]string{"log-level": "info", "aws-endpoint-url": "http://localhost:4566"}
+			},
+		},
+		{
+			name:    "invalid map",
+			path:    "run.flags",
+			value:   "log-level",
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name:  "map entry",
+			path:  "run.flags.log-level",
+			value: "info",
+			want:  func(c *Config) { c.RunConfig.Flags["log-level"] = "info" },
+		},
+		{
+			name:  "map entry containing a dot",
+			path:  `run.flags."leader.election-id"`,
+			value: "ecr",
+			want:  func(c *Config) { c.RunConfig.Flags["leader.election-id"] = "ecr" },
+		},
+		{
+			name:  "entry of a missing map",
+			path:  "run.profiles.dev.services.ecr.env.AWS_PROFILE",
+			value: "dev",
+			want: func(c *Config) {
+				c.RunConfig.Profiles = map[string]RunProfile{
+					"dev": {Services: map[string]ServiceRunConfig{
+						"ecr": {Env: map[string]string{"AWS_PROFILE": "dev"}},
+					}},
+				}
+			},
+		},
+		{
+			name:  "nested map entry",
+			path:  "run.services.s3.flags.aws-region",
+			value: "eu-west-1",
+			want: func(c *Config) {
+				c.RunConfig.Services["s3"] = ServiceRunConfig{
+					Flags: map[string]string{"log-level": "debug", "aws-region": "eu-west-1"},
+				}
+			},
+		},
+		{
+			name:  "JSON struct",
+			path:  "run.services.s3",
+			value: `{"env":{"AWS_PROFILE":"dev"}}`,
+			want: func(c *Config) {
+				c.RunConfig.Services["s3"] = ServiceRunConfig{Env: map[string]string{"AWS_PROFILE": "dev"}}
+			},
+		},
+		{
+			name:    "unknown path",
+			path:    "github.unknown",
+			value:   "x",
+			wantErr: ErrUnknownPath,
+		},
+		{
+			name:    "empty path",
+			path:    "",
+			value:   "x",
+			wantErr: ErrUnknownPath,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newPathTestConfig()
+			err := Set(cfg, tt.path, tt.value)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			want := newPathTestConfig()
+			tt.want(want)
+			assert.Equal(t, want, cfg)
+		})
+	}
+}
+
+func TestUnset(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    func(c *Config)
+		wantErr error
+	}{
+		{
+			name: "field",
+			path: "rootDirectory",
+			want: func(c *Config) { c.RootDirectory = "" },
+		},
+		{
+			name: "field with a default value",
+			path: "github.forkPrefix",
+			want: func(c *Config) { c.Github.ForkPrefix = "ack-" },
+		},
+		{
+			name: "list with a default value",
+			path: "repositories.core",
+			want: func(c *Config) { c.Repositories.Core = DefaultConfig.Repositories.Core },
+		},
+		{
+			name: "list",
+			path: "repositories.services",
+			want: func(c *Config) { c.Repositories.Services = nil },
+		},
+		{
+			name: "map entry",
+			path: "run.flags.aws-region",
+			want: func(c *Config) { delete(c.RunConfig.Flags, "aws-region") },
+		},
+		{
+			name: "map entry containing a dot",
+			path: `run.flags."leader.election-id"`,
+			want: func(c *Config) { delete(c.RunConfig.Flags, "leader.election-id") },
+		},
+		{
+			name: "nested map entry",
+			path: "run.services.s3.flags.log-level",
+			want: func(c *Config) { c.RunConfig.Services["s3"] = ServiceRunConfig{Flags: map[string]string{}} },
+		},
+		{
+			name:    "missing map entry",
+			path:    "run.profiles.dev.flags",
+			wantErr: ErrPathNotSet,
+		},
+		{
+			name:    "unknown path",
+			path:    "run.unknown",
+			wantErr: ErrUnknownPath,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newPathTestConfig()
+			err := Unset(cfg, tt.path)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error %v", err)
+				return
+			}
+			require.NoError(t, err)
+			want := newPathTestConfig()
+			tt.want(want)
+			assert.Equal(t, want, cfg)
+		})
+	}
+}
+
+func TestSave_Symlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "ackdev.yaml")
+	link := filepath.Join(dir, ".ackdev.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+	require.NoError(t, ioutil.WriteFile(target, []byte("apiVersion: v1alpha2\n"), 0644))
+	require.NoError(t, os.Symlink(target, link))
+
+	require.NoError(t, Save(newPathTestConfig(), link))
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.True(t, info.Mode()&os.ModeSymlink != 0, "the link was replaced")
+	info, err = os.Stat(target)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	cfg, err := Read(link)
+	require.NoError(t, err)
+	assert.Equal(t, "me-", cfg.Github.ForkPrefix)
+
+	// No temporary file is left behind
+	entries, err := ioutil.ReadDir(filepath.Dir(target))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
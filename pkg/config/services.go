@@ -0,0 +1,71 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+// knownServices are the AWS services with an ACK controller when this ackdev
+// version was released. Services missing from the list only trigger a
+// validation warning, since new controllers are regularly added.
+var knownServices = []string{
+	"acm",
+	"acmpca",
+	"apigateway",
+	"apigatewayv2",
+	"applicationautoscaling",
+	"athena",
+	"bedrockagent",
+	"cloudfront",
+	"cloudtrail",
+	"cloudwatch",
+	"cloudwatchlogs",
+	"codeartifact",
+	"cognitoidentityprovider",
+	"documentdb",
+	"dynamodb",
+	"ec2",
+	"ecr",
+	"ecs",
+	"efs",
+	"eks",
+	"elasticache",
+	"elbv2",
+	"emrcontainers",
+	"eventbridge",
+	"iam",
+	"kafka",
+	"keyspaces",
+	"kinesis",
+	"kms",
+	"lambda",
+	"memorydb",
+	"mq",
+	"networkfirewall",
+	"opensearchservice",
+	"organizations",
+	"pipes",
+	"prometheusservice",
+	"ram",
+	"rds",
+	"recyclebin",
+	"route53",
+	"route53resolver",
+	"s3",
+	"s3control",
+	"sagemaker",
+	"secretsmanager",
+	"sfn",
+	"sns",
+	"sqs",
+	"ssm",
+	"wafv2",
+}
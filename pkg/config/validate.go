@@ -0,0 +1,289 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/util"
+)
+
+var (
+	serviceNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	flagNameRegexp    = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+	envNameRegexp     = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// Operation identifies a group of ackdev commands requiring specific
+// configuration fields.
+type Operation string
+
+const (
+	// OperationGithub is the group of commands forking and cloning repositories
+	// or opening pull requests. They require a Github username.
+	OperationGithub Operation = "github"
+)
+
+// Operations is the list of supported operations
+var Operations = []Operation{OperationGithub}
+
+// Issue is a problem found in the configuration.
+type Issue struct {
+	// Path is the YAML path of the invalid field, e.g github.username
+	Path string `json:"path"`
+	// Message describes the problem
+	Message string `json:"message"`
+	// Warning tells whether the problem doesn't prevent ackdev from working
+	Warning bool `json:"warning,omitempty"`
+}
+
+// String returns the issue formatted as "path: message"
+func (i *Issue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// ValidationError is returned when the configuration contains errors. It
+// lists all of them.
+type ValidationError struct {
+	Issues []*Issue
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Issues)+1)
+	lines = append(lines, "invalid configuration:")
+	for _, issue := range e.Issues {
+		lines = append(lines, "  - "+issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate returns a ValidationError if the configuration contains errors,
+// including missing fields required by the given operations. Warnings are
+// ignored.
+func (c *Config) Validate(ops ...Operation) error {
+	errs := []*Issue{}
+	for _, issue := range c.Issues(ops...) {
+		if !issue.Warning {
+			errs = append(errs, issue)
+		}
+	}
+	if len(errs) > 0 {
+		return &ValidationError{Issues: errs}
+	}
+	return nil
+}
+
+// Issues returns all the errors and warnings found in the configuration,
+// including missing fields required by the given operations.
+func (c *Config) Issues(ops ...Operation) []*Issue {
+	v := &validator{}
+	c.validateRootDirectory(v)
+	c.validateRepositories(v)
+	c.validateGit(v)
+	c.validateGithub(v)
+	c.validateRunConfig(v)
+
+	for _, op := range ops {
+		switch op {
+		case OperationGithub:
+			if c.Github.Username == "" {
+				v.errorf("github.username", "is required to fork and clone repositories")
+			}
+		default:
+			v.errorf("", "unknown operation %s", op)
+		}
+	}
+	return v.issues
+}
+
+// validator collects the configuration issues
+type validator struct {
+	issues []*Issue
+}
+
+func (v *validator) errorf(path, format string, args ...interface{}) {
+	v.issues = append(v.issues, &Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) warnf(path, format string, args ...interface{}) {
+	v.issues = append(v.issues, &Issue{Path: path, Message: fmt.Sprintf(format, args...), Warning: true})
+}
+
+func (c *Config) validateRootDirectory(v *validator) {
+	const path = "rootDirectory"
+	if c.RootDirectory == "" {
+		v.errorf(path, "is required")
+		return
+	}
+	if !filepath.IsAbs(c.RootDirectory) {
+		v.errorf(path, "must be an absolute path, got %s", c.RootDirectory)
+		return
+	}
+	info, err := os.Stat(c.RootDirectory)
+	switch {
+	case os.IsNotExist(err):
+		v.warnf(path, "%s doesn't exist, it will be created when repositories are cloned", c.RootDirectory)
+	case err != nil:
+		v.errorf(path, "cannot access %s: %v", c.RootDirectory, err)
+	case !info.IsDir():
+		v.errorf(path, "%s is not a directory", c.RootDirectory)
+	}
+}
+
+func (c *Config) validateRepositories(v *validator) {
+	validateNames := func(path string, names []string, validate func(path, name string)) {
+		seen := map[string]bool{}
+		for i, name := range names {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if seen[name] {
+				v.errorf(itemPath, "duplicate repository %s", name)
+				continue
+			}
+			seen[name] = true
+			validate(itemPath, name)
+		}
+	}
+
+	validateNames("repositories.core", c.Repositories.Core, func(path, name string) {
+		if name == "" {
+			v.errorf(path, "repository name is empty")
+		}
+	})
+	validateNames("repositories.services", c.Repositories.Services, func(path, name string) {
+		switch {
+		case name == "":
+			v.errorf(path, "service name is empty")
+		case strings.HasSuffix(name, "-controller"):
+			v.errorf(path, "must be a service name without the -controller suffix, e.g %s", strings.TrimSuffix(name, "-controller"))
+		case !serviceNameRegexp.MatchString(name):
+			v.errorf(path, "invalid service name %s, service names are lower case alphanumeric, e.g s3", name)
+		case !util.InStrings(name, knownServices):
+			v.warnf(path, "unknown service %s, it doesn't match any ACK controller known to ackdev", name)
+		}
+	})
+}
+
+func (c *Config) validateGit(v *validator) {
+	switch c.Git.Protocol {
+	case "", GitProtocolHTTPS, GitProtocolSSH:
+	default:
+		v.errorf("git.protocol", "unsupported protocol %s, expected %s or %s", c.Git.Protocol, GitProtocolHTTPS, GitProtocolSSH)
+	}
+	validateReadableFile(v, "git.sshKeyPath", c.Git.SSHKeyPath)
+	validateReadableFile(v, "git.knownHostsPath", c.Git.KnownHostsPath)
+}
+
+// validateReadableFile checks that path, if set, is a readable file.
+func validateReadableFile(v *validator, fieldPath, path string) {
+	if path == "" {
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		v.errorf(fieldPath, "cannot read %s: %v", path, err)
+		return
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err == nil && info.IsDir() {
+		v.errorf(fieldPath, "%s is a directory", path)
+	}
+}
+
+func (c *Config) validateGithub(v *validator) {
+	if _, err := c.Github.GetForkTimeout(); err != nil {
+		v.errorf("github.forkTimeout", "invalid duration %s, e.g 90s or 5m", c.Github.ForkTimeout)
+	}
+	if c.Github.APIURL != "" {
+		u, err := url.Parse(c.Github.APIURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			v.errorf("github.apiURL", "invalid URL %s, e.g https://github.example.com/api/v3/", c.Github.APIURL)
+		}
+	}
+	if strings.Contains(c.Github.Host, "/") {
+		v.errorf("github.host", "must be a host name, e.g github.example.com, got %s", c.Github.Host)
+	}
+}
+
+func (c *Config) validateRunConfig(v *validator) {
+	validateFlags := func(path string, flags map[string]string) {
+		for _, name := range sortedKeys(flags) {
+			switch {
+			case strings.HasPrefix(name, "-"):
+				v.errorf(joinPath(path, name), "flag names must not start with dashes, use %s", strings.TrimLeft(name, "-"))
+			case !flagNameRegexp.MatchString(name):
+				v.errorf(joinPath(path, name), "invalid flag name")
+			}
+		}
+	}
+	validateEnv := func(path string, env map[string]string) {
+		for _, name := range sortedKeys(env) {
+			if !envNameRegexp.MatchString(name) {
+				v.errorf(joinPath(path, name), "invalid environment variable name")
+			}
+		}
+	}
+	validateServices := func(path string, services map[string]ServiceRunConfig) {
+		for _, service := range sortedServiceKeys(services) {
+			servicePath := joinPath(path, service)
+			if !util.InStrings(service, c.Repositories.Services) {
+				v.warnf(servicePath, "service %s isn't listed in repositories.services", service)
+			}
+			validateFlags(servicePath+".flags", services[service].Flags)
+			validateEnv(servicePath+".env", services[service].Env)
+		}
+	}
+
+	validateFlags("run.flags", c.RunConfig.Flags)
+	validateServices("run.services", c.RunConfig.Services)
+	profiles := make([]string, 0, len(c.RunConfig.Profiles))
+	for name := range c.RunConfig.Profiles {
+		profiles = append(profiles, name)
+	}
+	sort.Strings(profiles)
+	for _, name := range profiles {
+		profile := c.RunConfig.Profiles[name]
+		path := "run.profiles." + name
+		validateFlags(path+".flags", profile.Flags)
+		validateEnv(path+".env", profile.Env)
+		validateServices(path+".services", profile.Services)
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedServiceKeys(m map[string]ServiceRunConfig) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
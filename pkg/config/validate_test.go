@@ -0,0 +1,219 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Issues(t *testing.T) {
+	rootDirectory := t.TempDir()
+	file := filepath.Join(rootDirectory, "key")
+	require.NoError(t, ioutil.WriteFile(file, []byte("key"), 0600))
+
+	validConfig := func() *Config {
+		return &Config{
+			RootDirectory: rootDirectory,
+			Repositories: RepositoriesConfig{
+				Core:     []string{"runtime", "code-generator"},
+				Services: []string{"s3", "ecr"},
+			},
+			Git: GitConfig{
+				Protocol:   GitProtocolSSH,
+				SSHKeyPath: file,
+			},
+			Github: GithubConfig{
+				Username: "ack-bot",
+			},
+			RunConfig: RunConfig{
+				Flags: map[string]string{"aws-region": "us-west-2"},
+				Services: map[string]ServiceRunConfig{
+					"s3": {Env: map[string]string{"AWS_PROFILE": "dev"}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(c *Config)
+		ops    []Operation
+		want   []*Issue
+	}{
+		{
+			name:   "valid configuration",
+			mutate: func(c *Config) {},
+			ops:    []Operation{OperationGithub},
+			want:   nil,
+		},
+		{
+			name:   "missing root directory",
+			mutate: func(c *Config) { c.RootDirectory = "" },
+			want:   []*Issue{{Path: "rootDirectory", Message: "is required"}},
+		},
+		{
+			name:   "relative root directory",
+			mutate: func(c *Config) { c.RootDirectory = "ack" },
+			want:   []*Issue{{Path: "rootDirectory", Message: "must be an absolute path, got ack"}},
+		},
+		{
+			name:   "root directory is a file",
+			mutate: func(c *Config) { c.RootDirectory = file },
+			want:   []*Issue{{Path: "rootDirectory", Message: file + " is not a directory"}},
+		},
+		{
+			name:   "root directory doesn't exist",
+			mutate: func(c *Config) { c.RootDirectory = filepath.Join(rootDirectory, "ack") },
+			want: []*Issue{{
+				Path:    "rootDirectory",
+				Message: filepath.Join(rootDirectory, "ack") + " doesn't exist, it will be created when repositories are cloned",
+				Warning: true,
+			}},
+		},
+		{
+			name: "invalid service names",
+			mutate: func(c *Config) {
+				c.Repositories.Services = []string{"s3", "s3", "", "ecr-controller", "EC2"}
+			},
+			want: []*Issue{
+				{Path: "repositories.services[1]", Message: "duplicate repository s3"},
+				{Path: "repositories.services[2]", Message: "service name is empty"},
+				{Path: "repositories.services[3]", Message: "must be a service name without the -controller suffix, e.g ecr"},
+				{Path: "repositories.services[4]", Message: "invalid service name EC2, service names are lower case alphanumeric, e.g s3"},
+			},
+		},
+		{
+			name:   "unknown service name",
+			mutate: func(c *Config) { c.Repositories.Services = []string{"s3", "s4"} },
+			want: []*Issue{{
+				Path:    "repositories.services[1]",
+				Message: "unknown service s4, it doesn't match any ACK controller known to ackdev",
+				Warning: true,
+			}},
+		},
+		{
+			name:   "empty core repository",
+			mutate: func(c *Config) { c.Repositories.Core = []string{"runtime", ""} },
+			want:   []*Issue{{Path: "repositories.core[1]", Message: "repository name is empty"}},
+		},
+		{
+			name: "invalid git configuration",
+			mutate: func(c *Config) {
+				c.Git.Protocol = "ftp"
+				c.Git.KnownHostsPath = rootDirectory
+			},
+			want: []*Issue{
+				{Path: "git.protocol", Message: "unsupported protocol ftp, expected https or ssh"},
+				{Path: "git.knownHostsPath", Message: rootDirectory + " is a directory"},
+			},
+		},
+		{
+			name: "invalid github configuration",
+			mutate: func(c *Config) {
+				c.Github.ForkTimeout = "5 minutes"
+				c.Github.APIURL = "github.example.com"
+				c.Github.Host = "https://github.example.com"
+			},
+			want: []*Issue{
+				{Path: "github.forkTimeout", Message: "invalid duration 5 minutes, e.g 90s or 5m"},
+				{Path: "github.apiURL", Message: "invalid URL github.example.com, e.g https://github.example.com/api/v3/"},
+				{Path: "github.host", Message: "must be a host name, e.g github.example.com, got https://github.example.com"},
+			},
+		},
+		{
+			name: "invalid run configuration",
+			mutate: func(c *Config) {
+				c.RunConfig.Flags["--log-level"] = "debug"
+				c.RunConfig.Profiles = map[string]RunProfile{
+					"dev": {
+						Env: map[string]string{"1ENV": "x"},
+						Services: map[string]ServiceRunConfig{
+							"sqs": {Flags: map[string]string{"a flag": "x"}},
+						},
+					},
+				}
+			},
+			want: []*Issue{
+				{Path: "run.flags.--log-level", Message: "flag names must not start with dashes, use log-level"},
+				{Path: "run.profiles.dev.env.1ENV", Message: "invalid environment variable name"},
+				{Path: "run.profiles.dev.services.sqs", Message: "service sqs isn't listed in repositories.services", Warning: true},
+				{Path: "run.profiles.dev.services.sqs.flags.a flag", Message: "invalid flag name"},
+			},
+		},
+		{
+			name:   "missing github username",
+			mutate: func(c *Config) { c.Github.Username = "" },
+			ops:    []Operation{OperationGithub},
+			want:   []*Issue{{Path: "github.username", Message: "is required to fork and clone repositories"}},
+		},
+		{
+			name:   "github username isn't required without operation",
+			mutate: func(c *Config) { c.Github.Username = "" },
+			want:   nil,
+		},
+		{
+			name:   "unknown operation",
+			mutate: func(c *Config) {},
+			ops:    []Operation{"deploy"},
+			want:   []*Issue{{Message: "unknown operation deploy"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(cfg)
+			assert.Equal(t, tt.want, cfg.Issues(tt.ops...))
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := &Config{RootDirectory: filepath.Join(t.TempDir(), "ack")}
+	// Warnings are ignored
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Git.Protocol = "ftp"
+	err := cfg.Validate(OperationGithub)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Issues, 2)
+	assert.Equal(t, "invalid configuration:\n"+
+		"  - git.protocol: unsupported protocol ftp, expected https or ssh\n"+
+		"  - github.username: is required to fork and clone repositories", err.Error())
+}
+
+func TestLoadWithOptions_Invalid(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte("rootDirectory: ack\n"), 0600))
+
+	// The developer ACKDEV_* variables must not be applied
+	_, _, err := LoadWithOptions(configPath, LoadOptions{})
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+
+	cfg, err := Read(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "ack", cfg.RootDirectory)
+
+	// The configuration file is optional, but the defaults aren't enough
+	_, _, err = LoadWithOptions(filepath.Join(t.TempDir(), "missing.yaml"), LoadOptions{})
+	require.True(t, errors.As(err, &validationErr))
+	assert.Contains(t, err.Error(), "missing.yaml doesn't exist")
+}
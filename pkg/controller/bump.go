@@ -0,0 +1,220 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/util"
+)
+
+// BumpStatus is the outcome of bumping a dependency of a controller.
+type BumpStatus string
+
+const (
+	// BumpStatusBumped means that the dependency was updated and committed.
+	BumpStatusBumped BumpStatus = "BUMPED"
+	// BumpStatusPlanned means that the dependency would be updated, it's only
+	// used in dry run mode.
+	BumpStatusPlanned BumpStatus = "PLANNED"
+	// BumpStatusUpToDate means that the controller already uses the version.
+	BumpStatusUpToDate BumpStatus = "UP-TO-DATE"
+	// BumpStatusFailed means that an error occurred while bumping.
+	BumpStatusFailed BumpStatus = "FAILED"
+)
+
+var (
+	ErrUncommittedChanges = errors.New("repository contains uncommitted changes")
+)
+
+// BumpOptions contains the options used to bump a dependency of controllers.
+type BumpOptions struct {
+	// Module is the dependency module path, e.g RuntimeModule
+	Module string
+	// Version is the new version of the dependency
+	Version string
+	// ReplacePath is a local path the dependency is replaced by, e.g the
+	// runtime local clone. The dependency isn't replaced if empty.
+	ReplacePath string
+	// Branch is the branch created to commit the changes
+	Branch string
+	// CommitMessage is the message of the commit
+	CommitMessage string
+	// DryRun tells whether to only compute the go.mod changes
+	DryRun bool
+	// Regenerate tells whether the controllers should be regenerated after
+	// the bump
+	Regenerate bool
+	// CodeGeneratorPath is the path of the code-generator local clone, used
+	// when Regenerate is true
+	CodeGeneratorPath string
+}
+
+// BumpResult is the outcome of bumping a dependency of a single controller.
+type BumpResult struct {
+	// Repository is the controller repository
+	Repository *repository.Repository
+	// Status is the outcome of the bump
+	Status BumpStatus
+	// Diff is the go.mod diff, before go mod tidy
+	Diff string
+	// Commit is the hash of the bump commit
+	Commit string
+	// Err is the error that made the bump fail
+	Err error
+}
+
+// BumpResults is the list of results returned by BumpAll
+type BumpResults []*BumpResult
+
+// Failed returns the results of the controllers that failed to be bumped.
+func (rs BumpResults) Failed() BumpResults {
+	failed := BumpResults{}
+	for _, r := range rs {
+		if r.Status == BumpStatusFailed {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// BumpAll bumps a dependency of the given controllers using at most maxWorkers
+// goroutines. The controllers are regenerated one at a time, since they share
+// the code-generator clone. The returned results are in the same order as repos.
+func BumpAll(ctx context.Context, repos []*repository.Repository, opts BumpOptions, maxWorkers int) BumpResults {
+	results := make(BumpResults, len(repos))
+	var generateMu sync.Mutex
+	repository.ForEach(repos, maxWorkers, func(i int, repo *repository.Repository) {
+		results[i] = bump(ctx, repo, opts, &generateMu)
+	})
+	return results
+}
+
+// Bump updates a dependency in a controller go.mod file on a new branch, runs
+// go mod tidy, optionally regenerates the controller, and commits the changes.
+func Bump(ctx context.Context, repo *repository.Repository, opts BumpOptions) *BumpResult {
+	return bump(ctx, repo, opts, &sync.Mutex{})
+}
+
+func bump(ctx context.Context, repo *repository.Repository, opts BumpOptions, generateMu *sync.Mutex) *BumpResult {
+	result := &BumpResult{Repository: repo}
+	fail := func(err error) *BumpResult {
+		result.Status = BumpStatusFailed
+		result.Err = err
+		return result
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fail(err)
+	}
+
+	goModPath := filepath.Join(repo.FullPath, "go.mod")
+	before, after, err := PlanGoModUpdate(goModPath, opts)
+	if err != nil {
+		return fail(err)
+	}
+	if bytes.Equal(before, after) {
+		result.Status = BumpStatusUpToDate
+		return result
+	}
+	result.Diff = util.LineDiff(string(before), string(after))
+	if opts.DryRun {
+		result.Status = BumpStatusPlanned
+		return result
+	}
+
+	status, err := repo.Status()
+	if err != nil {
+		return fail(err)
+	}
+	if status.Dirty() {
+		return fail(ErrUncommittedChanges)
+	}
+	if err := repo.CreateBranch(opts.Branch); err != nil {
+		return fail(err)
+	}
+	result.Commit, err = commitBump(repo, goModPath, after, opts, generateMu)
+	if err != nil {
+		// Leave the clone as it was, so that the bump can be retried
+		if abortErr := repo.AbortBranch(opts.Branch); abortErr != nil {
+			return fail(fmt.Errorf("%v (cannot delete branch %s: %v)", err, opts.Branch, abortErr))
+		}
+		return fail(err)
+	}
+	result.Status = BumpStatusBumped
+	return result
+}
+
+// commitBump writes the updated go.mod, runs go mod tidy, optionally
+// regenerates the controller and commits the changes. It returns the commit
+// hash.
+func commitBump(repo *repository.Repository, goModPath string, goMod []byte, opts BumpOptions, generateMu *sync.Mutex) (string, error) {
+	if err := ioutil.WriteFile(goModPath, goMod, 0644); err != nil {
+		return "", err
+	}
+	if err := GoModTidy(repo.FullPath); err != nil {
+		return "", err
+	}
+
+	if opts.Regenerate {
+		versions, err := ResolveVersions(repo.FullPath)
+		if err != nil {
+			return "", err
+		}
+		generateMu.Lock()
+		err = Generate(&GenerateOptions{
+			Service:           strings.TrimSuffix(repo.Name, "-controller"),
+			CodeGeneratorPath: opts.CodeGeneratorPath,
+			ControllerPath:    repo.FullPath,
+			Versions:          versions,
+		})
+		generateMu.Unlock()
+		if err != nil {
+			return "", err
+		}
+	}
+	return repo.CommitAll(opts.CommitMessage)
+}
+
+// PlanGoModUpdate returns the content of a go.mod file before and after
+// bumping a dependency. Without ReplacePath, the replace directives of the
+// dependency pointing to a local directory are removed, otherwise the bumped
+// version wouldn't be used. The file isn't modified.
+func PlanGoModUpdate(goModPath string, opts BumpOptions) ([]byte, []byte, error) {
+	before, err := ioutil.ReadFile(goModPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	after, err := SetRequireVersion(before, opts.Module, opts.Version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.ReplacePath != "" {
+		after, err = SetReplace(after, opts.Module, opts.ReplacePath)
+	} else {
+		after, _, err = RemoveLocalReplace(after, opts.Module)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
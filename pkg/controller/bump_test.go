@@ -0,0 +1,164 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/testutil"
+)
+
+func TestPlanGoModUpdate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	goModPath := filepath.Join(t.TempDir(), "go.mod")
+	require.NoError(ioutil.WriteFile(goModPath, []byte(testGoMod), 0644))
+
+	before, after, err := PlanGoModUpdate(goModPath, BumpOptions{Module: RuntimeModule, Version: "v0.31.0"})
+	require.NoError(err)
+	assert.Equal(testGoMod, string(before))
+	assert.Contains(string(after), "github.com/aws-controllers-k8s/runtime v0.31.0")
+	// the local runtime clone would be used instead of the bumped version
+	assert.NotContains(string(after), "runtime => ../runtime")
+	assert.Contains(string(after), "replace github.com/go-logr/logr => github.com/go-logr/logr v1.2.0")
+
+	_, after, err = PlanGoModUpdate(goModPath, BumpOptions{Module: RuntimeModule, Version: "v0.30.0", ReplacePath: "../runtime"})
+	require.NoError(err)
+	assert.Equal(testGoMod, string(after))
+
+	_, _, err = PlanGoModUpdate(goModPath, BumpOptions{Module: "github.com/spf13/cobra", Version: "v1.8.0"})
+	assert.Error(err)
+}
+
+func TestBumpAll_DryRun(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	newController := func(name, goMod string) *repository.Repository {
+		path := t.TempDir()
+		require.NoError(ioutil.WriteFile(filepath.Join(path, "go.mod"), []byte(goMod), 0644))
+		return &repository.Repository{Name: name, FullPath: path}
+	}
+	goMod, _, err := RemoveReplace([]byte(testGoMod), RuntimeModule)
+	require.NoError(err)
+	upToDate, err := SetRequireVersion(goMod, RuntimeModule, "v0.31.0")
+	require.NoError(err)
+
+	repos := []*repository.Repository{
+		newController("s3-controller", string(goMod)),
+		newController("ecr-controller", string(upToDate)),
+		{Name: "sns-controller", FullPath: t.TempDir()},
+	}
+	results := BumpAll(context.TODO(), repos, BumpOptions{
+		Module:  RuntimeModule,
+		Version: "v0.31.0",
+		DryRun:  true,
+	}, 2)
+	require.Len(results, 3)
+
+	assert.Equal(BumpStatusPlanned, results[0].Status)
+	assert.Equal("@@ -6 +6 @@\n"+
+		"-\tgithub.com/aws-controllers-k8s/runtime v0.30.0\n"+
+		"+\tgithub.com/aws-controllers-k8s/runtime v0.31.0\n", results[0].Diff)
+	assert.Equal(BumpStatusUpToDate, results[1].Status)
+	assert.Equal(BumpStatusFailed, results[2].Status)
+	assert.Error(results[2].Err)
+	assert.Equal(BumpResults{results[2]}, results.Failed())
+
+	// dry run doesn't modify go.mod
+	content, err := ioutil.ReadFile(filepath.Join(repos[0].FullPath, "go.mod"))
+	require.NoError(err)
+	assert.Equal(string(goMod), string(content))
+}
+
+func TestBump_RollbackOnFailure(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// go mod tidy fails: the imported runtime package can't be downloaded.
+	t.Setenv("GOPROXY", "off")
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOTOOLCHAIN", "local")
+
+	cfg := testutil.NewConfig("s3")
+	cfg.RootDirectory = t.TempDir()
+	path := filepath.Join(cfg.RootDirectory, "s3-controller")
+	gitRepo, err := git.PlainInit(path, false)
+	require.NoError(err)
+	require.NoError(ioutil.WriteFile(filepath.Join(path, "go.mod"), []byte(testGoMod), 0644))
+	require.NoError(ioutil.WriteFile(filepath.Join(path, "main.go"), []byte(testMainGo), 0644))
+	worktree, err := gitRepo.Worktree()
+	require.NoError(err)
+	_, err = worktree.Add(".")
+	require.NoError(err)
+	_, err = worktree.Commit("Initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "ack-bot", Email: "ack-bot@ack", When: time.Now()},
+	})
+	require.NoError(err)
+	initialHead, err := gitRepo.Head()
+	require.NoError(err)
+
+	repoManager, err := repository.NewManager(cfg)
+	require.NoError(err)
+	repo, err := repoManager.LoadRepository("s3", repository.RepositoryTypeController)
+	require.NoError(err)
+
+	result := Bump(context.TODO(), repo, BumpOptions{
+		Module:        RuntimeModule,
+		Version:       "v0.31.0",
+		Branch:        "bump-runtime",
+		CommitMessage: "Bump runtime",
+	})
+	require.Equal(BumpStatusFailed, result.Status)
+	assert.Contains(result.Err.Error(), "go mod tidy failed")
+
+	// The clone is back on its original branch, with a clean worktree
+	head, err := gitRepo.Head()
+	require.NoError(err)
+	assert.Equal(initialHead.Name(), head.Name())
+	assert.Equal(initialHead.Hash(), head.Hash())
+	assert.Equal(initialHead.Name().Short(), repo.GitHead)
+	status, err := worktree.Status()
+	require.NoError(err)
+	assert.True(status.IsClean())
+	_, err = gitRepo.Reference(plumbing.NewBranchReferenceName("bump-runtime"), false)
+	assert.Equal(plumbing.ErrReferenceNotFound, err)
+
+	// The bump can be retried
+	result = Bump(context.TODO(), repo, BumpOptions{
+		Module:  RuntimeModule,
+		Version: "v0.31.0",
+		Branch:  "bump-runtime",
+	})
+	assert.Contains(result.Err.Error(), "go mod tidy failed")
+}
+
+const testMainGo = `package main
+
+import _ "github.com/aws-controllers-k8s/runtime/pkg/types"
+
+func main() {}
+`
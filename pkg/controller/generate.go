@@ -0,0 +1,102 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/asyncexec"
+)
+
+const (
+	// AWSSDKGoModule is the module path of the AWS SDK used by the controllers
+	AWSSDKGoModule = "github.com/aws/aws-sdk-go"
+	// RuntimeModule is the module path of the ACK runtime
+	RuntimeModule = "github.com/aws-controllers-k8s/runtime"
+
+	// buildControllerScript is the code-generator script generating a controller
+	buildControllerScript = "./scripts/build-controller.sh"
+)
+
+// Versions contains the versions of the dependencies a controller is
+// generated against.
+type Versions struct {
+	// AWSSDKGo is the aws-sdk-go version
+	AWSSDKGo string
+	// Runtime is the ACK runtime version
+	Runtime string
+}
+
+// ResolveVersions returns the AWS SDK and ACK runtime versions pinned in the
+// controller go.mod file.
+func ResolveVersions(controllerPath string) (*Versions, error) {
+	goModPath := filepath.Join(controllerPath, "go.mod")
+	sdkVersion, err := ModuleVersion(goModPath, AWSSDKGoModule)
+	if err != nil {
+		return nil, err
+	}
+	runtimeVersion, err := ModuleVersion(goModPath, RuntimeModule)
+	if err != nil {
+		return nil, err
+	}
+	return &Versions{
+		AWSSDKGo: sdkVersion,
+		Runtime:  runtimeVersion,
+	}, nil
+}
+
+// GenerateOptions contains the options used to generate a controller.
+type GenerateOptions struct {
+	// Service is the AWS service name, e.g s3
+	Service string
+	// CodeGeneratorPath is the path of the code-generator local clone
+	CodeGeneratorPath string
+	// ControllerPath is the path of the controller local clone
+	ControllerPath string
+	// Versions are the dependencies versions the controller is generated against
+	Versions *Versions
+}
+
+// Generate runs the code-generator build scripts against a controller
+// repository. The generator output is streamed to stdout/stderr.
+func Generate(opts *GenerateOptions) error {
+	err := asyncexec.StreamCommand(
+		opts.CodeGeneratorPath,
+		generateEnv(opts),
+		buildControllerScript,
+		[]string{opts.Service},
+	)
+	if err != nil {
+		return fmt.Errorf("cannot generate %s controller: %v", opts.Service, err)
+	}
+	return nil
+}
+
+// generateEnv returns the environment variables read by the code-generator
+// build scripts.
+func generateEnv(opts *GenerateOptions) []string {
+	env := []string{
+		"SERVICE_CONTROLLER_SOURCE_PATH=" + opts.ControllerPath,
+	}
+	if opts.Versions != nil {
+		if opts.Versions.AWSSDKGo != "" {
+			env = append(env, "AWS_SDK_GO_VERSION="+opts.Versions.AWSSDKGo)
+		}
+		if opts.Versions.Runtime != "" {
+			env = append(env, "ACK_RUNTIME_VERSION="+opts.Versions.Runtime)
+		}
+	}
+	return env
+}
@@ -0,0 +1,74 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package controller
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveVersions(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	controllerPath := t.TempDir()
+	_, err := ResolveVersions(controllerPath)
+	assert.Error(err)
+
+	require.NoError(ioutil.WriteFile(filepath.Join(controllerPath, "go.mod"), []byte(testGoMod), 0644))
+	versions, err := ResolveVersions(controllerPath)
+	require.NoError(err)
+	assert.Equal(&Versions{AWSSDKGo: "v1.49.0", Runtime: "v0.30.0"}, versions)
+}
+
+func TestGenerate(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	codeGeneratorPath := t.TempDir()
+	outputPath := filepath.Join(t.TempDir(), "output")
+	require.NoError(os.MkdirAll(filepath.Join(codeGeneratorPath, "scripts"), 0755))
+
+	// fake build script recording its arguments and environment
+	script := "#!/bin/sh\n" +
+		"echo \"$1 $SERVICE_CONTROLLER_SOURCE_PATH $AWS_SDK_GO_VERSION $ACK_RUNTIME_VERSION\" > " + outputPath + "\n" +
+		"[ \"$1\" != \"fail\" ]\n"
+	require.NoError(ioutil.WriteFile(filepath.Join(codeGeneratorPath, buildControllerScript), []byte(script), 0755))
+
+	err := Generate(&GenerateOptions{
+		Service:           "s3",
+		CodeGeneratorPath: codeGeneratorPath,
+		ControllerPath:    "/src/s3-controller",
+		Versions:          &Versions{AWSSDKGo: "v1.49.0", Runtime: "v0.30.0"},
+	})
+	require.NoError(err)
+	output, err := ioutil.ReadFile(outputPath)
+	require.NoError(err)
+	assert.Equal("s3 /src/s3-controller v1.49.0 v0.30.0\n", string(output))
+
+	err = Generate(&GenerateOptions{
+		Service:           "fail",
+		CodeGeneratorPath: codeGeneratorPath,
+		ControllerPath:    "/src/fail-controller",
+	})
+	assert.Error(err)
+}
@@ -0,0 +1,230 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+var (
+	ErrModuleNotFound = errors.New("module not found in go.mod")
+)
+
+// ModuleVersion returns the version of a module required in a go.mod file. If
+// the module is replaced by another versioned module, the replacement version
+// is returned.
+func ModuleVersion(goModPath string, module string) (string, error) {
+	content, err := ioutil.ReadFile(goModPath)
+	if err != nil {
+		return "", err
+	}
+	f, err := modfile.Parse(goModPath, content, nil)
+	if err != nil {
+		return "", err
+	}
+
+	for _, r := range f.Replace {
+		if r.Old.Path == module && r.New.Version != "" {
+			return r.New.Version, nil
+		}
+	}
+	for _, r := range f.Require {
+		if r.Mod.Path == module {
+			return r.Mod.Version, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrModuleNotFound, module)
+}
+
+// SetRequireVersion returns the go.mod content with the required version of a
+// module set to version. It returns ErrModuleNotFound if the module isn't
+// required.
+func SetRequireVersion(content []byte, module, version string) ([]byte, error) {
+	f, err := parseGoMod(content)
+	if err != nil {
+		return nil, err
+	}
+	found := false
+	for _, r := range f.Require {
+		found = found || r.Mod.Path == module
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrModuleNotFound, module)
+	}
+	if err := f.AddRequire(module, version); err != nil {
+		return nil, err
+	}
+	// Nothing was dropped, there's nothing to clean up
+	return f.Format()
+}
+
+// SetReplace returns the go.mod content with all the versions of a module
+// replaced by replacement, e.g a local path. Existing replace directives of
+// the module are removed. The content is returned unchanged if the module is
+// already replaced by replacement.
+func SetReplace(content []byte, module, replacement string) ([]byte, error) {
+	replaces, err := Replaces(content, module)
+	if err != nil {
+		return nil, err
+	}
+	if len(replaces) == 1 && replaces[0] == module+" => "+replacement {
+		return content, nil
+	}
+
+	f, err := parseGoMod(content)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dropReplaces(f, module, nil); err != nil {
+		return nil, err
+	}
+	if err := f.AddReplace(module, "", replacement, ""); err != nil {
+		return nil, err
+	}
+	return formatGoMod(f)
+}
+
+// RemoveReplace returns the go.mod content without the replace directives of
+// a module, and whether a directive was removed.
+func RemoveReplace(content []byte, module string) ([]byte, bool, error) {
+	return removeReplaces(content, module, nil)
+}
+
+// RemoveLocalReplace returns the go.mod content without the replace
+// directives of a module pointing to a local directory, e.g ../runtime, and
+// whether a directive was removed.
+func RemoveLocalReplace(content []byte, module string) ([]byte, bool, error) {
+	return removeReplaces(content, module, func(r *modfile.Replace) bool {
+		return modfile.IsDirectoryPath(r.New.Path)
+	})
+}
+
+// Replaces returns the replace directives of a module, without the replace
+// keyword, e.g "github.com/aws-controllers-k8s/runtime => ../runtime".
+func Replaces(content []byte, module string) ([]string, error) {
+	f, err := parseGoMod(content)
+	if err != nil {
+		return nil, err
+	}
+	replaces := []string{}
+	for _, r := range f.Replace {
+		if r.Old.Path == module {
+			replaces = append(replaces, fmt.Sprintf("%s => %s", formatModule(r.Old), formatModule(r.New)))
+		}
+	}
+	return replaces, nil
+}
+
+// AddReplaces returns the go.mod content with replace directives, as returned
+// by Replaces, added.
+func AddReplaces(content []byte, replaces ...string) ([]byte, error) {
+	if len(replaces) == 0 {
+		return content, nil
+	}
+	f, err := parseGoMod(content)
+	if err != nil {
+		return nil, err
+	}
+	for _, replace := range replaces {
+		parts := strings.Split(replace, " => ")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid replace directive %q", replace)
+		}
+		oldPath, oldVersion := parseModule(parts[0])
+		newPath, newVersion := parseModule(parts[1])
+		if err := f.AddReplace(oldPath, oldVersion, newPath, newVersion); err != nil {
+			return nil, err
+		}
+	}
+	return formatGoMod(f)
+}
+
+// GoModTidy runs go mod tidy in a module directory.
+func GoModTidy(modulePath string) error {
+	cmd := exec.Command("go", "mod", "tidy")
+	cmd.Dir = modulePath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("go mod tidy failed: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func parseGoMod(content []byte) (*modfile.File, error) {
+	return modfile.Parse("go.mod", content, nil)
+}
+
+func formatGoMod(f *modfile.File) ([]byte, error) {
+	f.Cleanup()
+	return f.Format()
+}
+
+// removeReplaces removes the replace directives of a module matching filter,
+// or all of them if filter is nil.
+func removeReplaces(content []byte, module string, filter func(*modfile.Replace) bool) ([]byte, bool, error) {
+	f, err := parseGoMod(content)
+	if err != nil {
+		return nil, false, err
+	}
+	dropped, err := dropReplaces(f, module, filter)
+	if err != nil {
+		return nil, false, err
+	}
+	if !dropped {
+		return content, false, nil
+	}
+	content, err = formatGoMod(f)
+	return content, true, err
+}
+
+// dropReplaces drops the replace directives of a module matching filter, or
+// all of them if filter is nil, and returns whether a directive was dropped.
+func dropReplaces(f *modfile.File, module string, filter func(*modfile.Replace) bool) (bool, error) {
+	dropped := false
+	for _, r := range f.Replace {
+		if r.Old.Path != module || (filter != nil && !filter(r)) {
+			continue
+		}
+		if err := f.DropReplace(r.Old.Path, r.Old.Version); err != nil {
+			return false, err
+		}
+		dropped = true
+	}
+	return dropped, nil
+}
+
+// formatModule formats a module path and its optional version, as in replace
+// directives.
+func formatModule(m module.Version) string {
+	if m.Version == "" {
+		return m.Path
+	}
+	return m.Path + " " + m.Version
+}
+
+// parseModule parses a module formatted by formatModule.
+func parseModule(s string) (string, string) {
+	fields := strings.Fields(s)
+	if len(fields) == 2 {
+		return fields[0], fields[1]
+	}
+	return strings.TrimSpace(s), ""
+}
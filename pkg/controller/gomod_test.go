@@ -0,0 +1,162 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGoMod = `module github.com/aws-controllers-k8s/s3-controller
+
+go 1.21
+
+require (
+	github.com/aws-controllers-k8s/runtime v0.30.0
+	github.com/aws/aws-sdk-go v1.49.0 // indirect
+	github.com/go-logr/logr v1.3.0
+)
+
+require github.com/spf13/pflag v1.0.5
+
+replace github.com/go-logr/logr => github.com/go-logr/logr v1.2.0
+
+replace (
+	// local runtime clone
+	github.com/aws-controllers-k8s/runtime => ../runtime
+)
+`
+
+func TestModuleVersion(t *testing.T) {
+	require := require.New(t)
+
+	goModPath := filepath.Join(t.TempDir(), "go.mod")
+	require.NoError(ioutil.WriteFile(goModPath, []byte(testGoMod), 0644))
+
+	tests := []struct {
+		module      string
+		wantVersion string
+		wantErr     error
+	}{
+		{module: RuntimeModule, wantVersion: "v0.30.0"},
+		{module: AWSSDKGoModule, wantVersion: "v1.49.0"},
+		{module: "github.com/spf13/pflag", wantVersion: "v1.0.5"},
+		{module: "github.com/go-logr/logr", wantVersion: "v1.2.0"},
+		{module: "github.com/spf13/cobra", wantErr: ErrModuleNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.module, func(t *testing.T) {
+			assert := assert.New(t)
+
+			version, err := ModuleVersion(goModPath, tt.module)
+			if tt.wantErr != nil {
+				assert.True(errors.Is(err, tt.wantErr))
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.wantVersion, version)
+		})
+	}
+
+	_, err := ModuleVersion(filepath.Join(t.TempDir(), "go.mod"), RuntimeModule)
+	assert.Error(t, err)
+}
+
+func TestSetRequireVersion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	content, err := SetRequireVersion([]byte(testGoMod), RuntimeModule, "v0.31.0")
+	require.NoError(err)
+	assert.Equal(strings.Replace(testGoMod, "runtime v0.30.0", "runtime v0.31.0", 1), string(content))
+
+	content, err = SetRequireVersion([]byte(testGoMod), AWSSDKGoModule, "v1.50.0")
+	require.NoError(err)
+	assert.Contains(string(content), "\tgithub.com/aws/aws-sdk-go v1.50.0 // indirect\n")
+
+	_, err = SetRequireVersion([]byte(testGoMod), "github.com/spf13/cobra", "v1.8.0")
+	assert.True(errors.Is(err, ErrModuleNotFound))
+
+	_, err = SetRequireVersion([]byte("require ("), RuntimeModule, "v0.31.0")
+	assert.Error(err)
+}
+
+func TestSetReplace(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	content, err := SetReplace([]byte(testGoMod), RuntimeModule, "/src/runtime")
+	require.NoError(err)
+	assert.NotContains(string(content), "runtime => ../runtime")
+	assert.Contains(string(content), "replace github.com/aws-controllers-k8s/runtime => /src/runtime\n")
+	assert.Contains(string(content), "replace github.com/go-logr/logr => github.com/go-logr/logr v1.2.0")
+
+	// already replaced
+	replaced, err := SetReplace(content, RuntimeModule, "/src/runtime")
+	require.NoError(err)
+	assert.Equal(content, replaced)
+}
+
+func TestRemoveReplace(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	content, removed, err := RemoveReplace([]byte(testGoMod), RuntimeModule)
+	require.NoError(err)
+	assert.True(removed)
+	assert.NotContains(string(content), "=> ../runtime")
+	assert.Contains(string(content), "github.com/aws-controllers-k8s/runtime v0.30.0")
+
+	_, removed, err = RemoveReplace(content, RuntimeModule)
+	require.NoError(err)
+	assert.False(removed)
+
+	// Only local replace directives are removed
+	content, removed, err = RemoveLocalReplace([]byte(testGoMod), RuntimeModule)
+	require.NoError(err)
+	assert.True(removed)
+	assert.NotContains(string(content), "=> ../runtime")
+	_, removed, err = RemoveLocalReplace([]byte(testGoMod), "github.com/go-logr/logr")
+	require.NoError(err)
+	assert.False(removed)
+}
+
+func TestReplacesAndAddReplaces(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	replaces, err := Replaces([]byte(testGoMod), RuntimeModule)
+	require.NoError(err)
+	assert.Equal([]string{"github.com/aws-controllers-k8s/runtime => ../runtime"}, replaces)
+	replaces, err = Replaces([]byte(testGoMod), "github.com/go-logr/logr")
+	require.NoError(err)
+	assert.Equal([]string{"github.com/go-logr/logr => github.com/go-logr/logr v1.2.0"}, replaces)
+
+	content, _, err := RemoveReplace([]byte(testGoMod), "github.com/go-logr/logr")
+	require.NoError(err)
+	content, err = AddReplaces(content, replaces...)
+	require.NoError(err)
+	restored, err := Replaces(content, "github.com/go-logr/logr")
+	require.NoError(err)
+	assert.Equal(replaces, restored)
+
+	_, err = AddReplaces(content, "github.com/go-logr/logr")
+	assert.Error(err)
+}
@@ -0,0 +1,185 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/asyncexec"
+)
+
+const (
+	// binaryPath is the path of the controller binary, relative to the
+	// controller repository.
+	binaryPath = "bin/controller"
+	// mainPackage is the controller main package, relative to the controller
+	// repository.
+	mainPackage = "./cmd/controller"
+
+	// defaultGracePeriod is the time given to the controller to exit after it
+	// received a SIGTERM.
+	defaultGracePeriod = 10 * time.Second
+	// defaultWatchInterval is the interval between two checks of the
+	// controller Go files.
+	defaultWatchInterval = time.Second
+)
+
+var (
+	ErrControllerExited = errors.New("controller exited")
+)
+
+// Build compiles the controller binary of a local controller repository and
+// returns the binary path.
+func Build(controllerPath string) (string, error) {
+	output := filepath.Join(controllerPath, binaryPath)
+	err := asyncexec.StreamCommand(controllerPath, nil, "go", []string{"build", "-o", output, mainPackage})
+	if err != nil {
+		return "", fmt.Errorf("cannot build controller: %v", err)
+	}
+	return output, nil
+}
+
+// FlagArgs converts a map of flags to a list of command line arguments, sorted
+// by flag name. e.g {"aws-region": "us-west-2"} becomes ["--aws-region=us-west-2"]
+func FlagArgs(flags map[string]string) []string {
+	args := make([]string, 0, len(flags))
+	for name, value := range flags {
+		args = append(args, fmt.Sprintf("--%s=%s", name, value))
+	}
+	sort.Strings(args)
+	return args
+}
+
+// RunOptions contains the options used to run a controller locally.
+type RunOptions struct {
+	// ControllerPath is the path of the controller local clone
+	ControllerPath string
+	// Flags are the flags passed to the controller binary
+	Flags map[string]string
+	// Env is the list of environment variables, formatted as KEY=VALUE, added
+	// to the controller environment.
+	Env []string
+	// SkipBuild tells whether the existing controller binary should be used
+	// instead of building a new one.
+	SkipBuild bool
+	// Watch tells whether the controller should be rebuilt and restarted
+	// when a Go file of the controller repository changes.
+	Watch bool
+	// GracePeriod is the time given to the controller to exit once it's
+	// stopped. Defaults to 10 seconds.
+	GracePeriod time.Duration
+}
+
+// Run builds and runs a controller until ctx is done or the controller exits.
+// The controller output is streamed to stdout/stderr. When ctx is done, the
+// controller receives a SIGTERM and is killed if it's still running after the
+// grace period.
+func Run(ctx context.Context, opts *RunOptions) error {
+	gracePeriod := opts.GracePeriod
+	if gracePeriod == 0 {
+		gracePeriod = defaultGracePeriod
+	}
+
+	var changes <-chan struct{}
+	if opts.Watch {
+		changes = WatchGoFiles(ctx, opts.ControllerPath, defaultWatchInterval)
+	}
+
+	skipBuild := opts.SkipBuild
+	for ctx.Err() == nil {
+		binary := filepath.Join(opts.ControllerPath, binaryPath)
+		if !skipBuild {
+			var err error
+			binary, err = Build(opts.ControllerPath)
+			if err != nil {
+				if !opts.Watch {
+					return err
+				}
+				// Wait for the next change to rebuild the controller
+				fmt.Fprintln(os.Stderr, err)
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-changes:
+					continue
+				}
+			}
+		}
+		// The binary is always rebuilt after a change
+		skipBuild = false
+
+		restart, err := runOnce(ctx, binary, opts, gracePeriod, changes)
+		if err != nil || !restart {
+			return err
+		}
+		fmt.Println("Go files changed, rebuilding controller")
+	}
+	return nil
+}
+
+// runOnce runs the controller binary until ctx is done, the controller exits
+// or a change is received. It returns true if the controller was stopped
+// because of a change.
+func runOnce(ctx context.Context, binary string, opts *RunOptions, gracePeriod time.Duration, changes <-chan struct{}) (bool, error) {
+	cmd := exec.Command(binary, FlagArgs(opts.Flags)...)
+	cmd.Dir = opts.ControllerPath
+	cmd.Env = append(os.Environ(), opts.Env...)
+
+	acmd := asyncexec.New(cmd, 64)
+	err := acmd.Run()
+	if err != nil {
+		return false, err
+	}
+
+	go streamLines(acmd.StdoutStream(), os.Stdout)
+	go streamLines(acmd.StderrStream(), os.Stderr)
+
+	var waitErr error
+	done := make(chan struct{})
+	go func() {
+		waitErr = acmd.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		// Interrupts sent from a terminal are also received by the controller,
+		// which can exit before ctx is done.
+		if ctx.Err() != nil {
+			return false, nil
+		}
+		if waitErr != nil {
+			return false, fmt.Errorf("%w: %v", ErrControllerExited, waitErr)
+		}
+		return false, fmt.Errorf("%w with code %d", ErrControllerExited, acmd.ExitCode())
+	case <-ctx.Done():
+		return false, acmd.Terminate(syscall.SIGTERM, gracePeriod, done)
+	case <-changes:
+		return true, acmd.Terminate(syscall.SIGTERM, gracePeriod, done)
+	}
+}
+
+func streamLines(lines <-chan []byte, w *os.File) {
+	for line := range lines {
+		_, _ = w.Write(append(line, '\n'))
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(FlagArgs(nil))
+	assert.Equal([]string{
+		"--aws-region=us-west-2",
+		"--enable-development-logging=true",
+		"--log-level=debug",
+	}, FlagArgs(map[string]string{
+		"log-level":                  "debug",
+		"aws-region":                 "us-west-2",
+		"enable-development-logging": "true",
+	}))
+}
+
+// newFakeController creates a controller repository containing a fake controller
+// binary. The binary writes its arguments and environment to an output file, then
+// runs the given shell commands.
+func newFakeController(t *testing.T, commands string) (controllerPath, outputPath string) {
+	controllerPath = t.TempDir()
+	outputPath = filepath.Join(controllerPath, "output")
+	require.NoError(t, os.MkdirAll(filepath.Join(controllerPath, "bin"), 0755))
+
+	script := "#!/bin/sh\n" +
+		"echo \"$@ $ACK_TEST_ENV\" > " + outputPath + "\n" +
+		commands + "\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(controllerPath, binaryPath), []byte(script), 0755))
+	return controllerPath, outputPath
+}
+
+func TestRun(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// controller exiting on its own
+	controllerPath, outputPath := newFakeController(t, "exit 3")
+	err := Run(context.TODO(), &RunOptions{
+		ControllerPath: controllerPath,
+		Flags:          map[string]string{"aws-region": "us-west-2"},
+		Env:            []string{"ACK_TEST_ENV=test"},
+		SkipBuild:      true,
+	})
+	assert.True(errors.Is(err, ErrControllerExited))
+	output, err := ioutil.ReadFile(outputPath)
+	require.NoError(err)
+	assert.Equal("--aws-region=us-west-2 test\n", string(output))
+
+	// controller stopped gracefully
+	controllerPath, _ = newFakeController(t, "trap 'exit 0' TERM\nwhile true; do sleep 0.01; done")
+	ctx, cancel := context.WithTimeout(context.TODO(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = Run(ctx, &RunOptions{
+		ControllerPath: controllerPath,
+		SkipBuild:      true,
+		GracePeriod:    5 * time.Second,
+	})
+	assert.NoError(err)
+	assert.Less(int64(time.Since(start)), int64(5*time.Second))
+
+	// controller ignoring SIGTERM is killed after the grace period
+	controllerPath, _ = newFakeController(t, "trap '' TERM\nwhile true; do sleep 0.01; done")
+	ctx, cancel = context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	err = Run(ctx, &RunOptions{
+		ControllerPath: controllerPath,
+		SkipBuild:      true,
+		GracePeriod:    100 * time.Millisecond,
+	})
+	assert.NoError(err)
+}
+
+func TestWatchGoFiles(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	root := t.TempDir()
+	require.NoError(os.MkdirAll(filepath.Join(root, "vendor"), 0755))
+	require.NoError(ioutil.WriteFile(filepath.Join(root, "main.go"), []byte("package main"), 0644))
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	changes := WatchGoFiles(ctx, root, 10*time.Millisecond)
+
+	expectChange := func(want bool) {
+		select {
+		case <-changes:
+			assert.True(want, "unexpected change")
+		case <-time.After(100 * time.Millisecond):
+			assert.False(want, "change not detected")
+		}
+	}
+
+	// ignored files
+	require.NoError(ioutil.WriteFile(filepath.Join(root, "README.md"), []byte("# s3"), 0644))
+	require.NoError(ioutil.WriteFile(filepath.Join(root, "vendor", "dep.go"), []byte("package dep"), 0644))
+	expectChange(false)
+
+	require.NoError(ioutil.WriteFile(filepath.Join(root, "resource.go"), []byte("package main"), 0644))
+	expectChange(true)
+	require.NoError(os.Remove(filepath.Join(root, "main.go")))
+	expectChange(true)
+
+	cancel()
+	_, ok := <-changes
+	assert.False(ok)
+}
@@ -0,0 +1,263 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package credentials
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+)
+
+var (
+	ErrTokenNotFound     = errors.New("github token not found")
+	ErrUnknownSourceName = errors.New("unknown credential source")
+)
+
+const (
+	// SourceEnv resolves the token from an environment variable.
+	SourceEnv = "env"
+	// SourceCommand resolves the token from the output of a command.
+	SourceCommand = "command"
+	// SourceGitCredential resolves the token using the git credential helpers.
+	SourceGitCredential = "git-credential"
+	// SourceConfig resolves the token from the ackdev configuration file.
+	SourceConfig = "config"
+
+	DefaultEnvVariable = "GITHUB_TOKEN"
+	DefaultCommand     = "gh auth token"
+	DefaultGitHost     = "github.com"
+
+	defaultCommandTimeout = 10 * time.Second
+)
+
+// DefaultSources is the default order in which the Github token sources are
+// tried.
+var DefaultSources = []string{SourceConfig, SourceEnv}
+
+// Provider is the interface implemented by the Github token sources.
+type Provider interface {
+	// Name returns the name of the source.
+	Name() string
+	// Retrieve returns the Github token. It returns ErrTokenNotFound if
+	// the source doesn't provide any token.
+	Retrieve() (string, error)
+}
+
+// NewProviderFromConfig returns a ChainProvider trying the sources listed in
+// the Github credentials configuration, in order.
+func NewProviderFromConfig(cfg *config.GithubConfig) (*ChainProvider, error) {
+	sources := cfg.Credentials.Sources
+	if len(sources) == 0 {
+		sources = DefaultSources
+	}
+
+	providers := make([]Provider, 0, len(sources))
+	for _, source := range sources {
+		switch source {
+		case SourceEnv:
+			providers = append(providers, &EnvProvider{Variable: cfg.Credentials.EnvVariable})
+		case SourceCommand:
+			providers = append(providers, &CommandProvider{Command: cfg.Credentials.Command})
+		case SourceGitCredential:
+			providers = append(providers, &GitCredentialProvider{Host: cfg.GetHost()})
+		case SourceConfig:
+			providers = append(providers, &StaticProvider{Token: cfg.Token})
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSourceName, source)
+		}
+	}
+	return NewChainProvider(providers...), nil
+}
+
+// StaticProvider provides the token stored in the ackdev configuration file.
+type StaticProvider struct {
+	Token string
+}
+
+// Name implements Provider.Name
+func (p *StaticProvider) Name() string { return SourceConfig }
+
+// Retrieve implements Provider.Retrieve
+func (p *StaticProvider) Retrieve() (string, error) {
+	if p.Token == "" {
+		return "", ErrTokenNotFound
+	}
+	return p.Token, nil
+}
+
+// EnvProvider provides the token stored in an environment variable. Defaults
+// to GITHUB_TOKEN.
+type EnvProvider struct {
+	Variable string
+}
+
+// Name implements Provider.Name
+func (p *EnvProvider) Name() string { return SourceEnv }
+
+// Retrieve implements Provider.Retrieve
+func (p *EnvProvider) Retrieve() (string, error) {
+	variable := p.Variable
+	if variable == "" {
+		variable = DefaultEnvVariable
+	}
+	token := strings.TrimSpace(os.Getenv(variable))
+	if token == "" {
+		return "", ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// CommandProvider provides the token printed by a command. Defaults to
+// 'gh auth token'.
+type CommandProvider struct {
+	Command string
+}
+
+// Name implements Provider.Name
+func (p *CommandProvider) Name() string { return SourceCommand }
+
+// Retrieve implements Provider.Retrieve
+func (p *CommandProvider) Retrieve() (string, error) {
+	command := p.Command
+	if command == "" {
+		command = DefaultCommand
+	}
+	args := strings.Fields(command)
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCommandTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, args[0], args[1:]...).Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return "", ErrTokenNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot get github token from '%s': %v", command, err)
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// GitCredentialProvider provides the password returned by the configured git
+// credential helpers for the Github host.
+type GitCredentialProvider struct {
+	Host string
+}
+
+// Name implements Provider.Name
+func (p *GitCredentialProvider) Name() string { return SourceGitCredential }
+
+// Retrieve implements Provider.Retrieve
+func (p *GitCredentialProvider) Retrieve() (string, error) {
+	host := p.Host
+	if host == "" {
+		host = DefaultGitHost
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=https\nhost=%s\n\n", host))
+	// Never let git prompt for credentials
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return "", ErrTokenNotFound
+	}
+	if err != nil {
+		// git credential fill fails when no helper can provide credentials
+		return "", ErrTokenNotFound
+	}
+	return parseGitCredentialPassword(out)
+}
+
+// parseGitCredentialPassword returns the password attribute of a git credential
+// helper output.
+func parseGitCredentialPassword(out []byte) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		if found && key == "password" && value != "" {
+			return value, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", ErrTokenNotFound
+}
+
+// NewChainProvider returns a ChainProvider trying the given providers in order.
+func NewChainProvider(providers ...Provider) *ChainProvider {
+	return &ChainProvider{providers: providers}
+}
+
+// ChainProvider returns the token of the first provider that has one. The
+// token is only resolved once and then cached.
+type ChainProvider struct {
+	providers []Provider
+
+	once   sync.Once
+	token  string
+	source string
+	err    error
+}
+
+// Name implements Provider.Name
+func (c *ChainProvider) Name() string {
+	names := make([]string, 0, len(c.providers))
+	for _, p := range c.providers {
+		names = append(names, p.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+// Retrieve implements Provider.Retrieve
+func (c *ChainProvider) Retrieve() (string, error) {
+	c.once.Do(func() {
+		for _, p := range c.providers {
+			token, err := p.Retrieve()
+			if err == ErrTokenNotFound {
+				continue
+			}
+			if err != nil {
+				c.err = err
+				return
+			}
+			c.token, c.source = token, p.Name()
+			return
+		}
+		c.err = fmt.Errorf("%w in sources: %s", ErrTokenNotFound, c.Name())
+	})
+	return c.token, c.err
+}
+
+// Source returns the name of the provider the token was resolved from. It
+// returns an empty string if the token wasn't resolved yet.
+func (c *ChainProvider) Source() string {
+	return c.source
+}
@@ -0,0 +1,157 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package credentials
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+)
+
+type fakeProvider struct {
+	name  string
+	token string
+	err   error
+	calls int
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) Retrieve() (string, error) {
+	p.calls++
+	return p.token, p.err
+}
+
+func TestChainProvider_Retrieve(t *testing.T) {
+	assert := assert.New(t)
+
+	unknownErr := errors.New("unknown error")
+	tests := []struct {
+		name       string
+		providers  []Provider
+		wantToken  string
+		wantSource string
+		wantErr    error
+	}{
+		{
+			name:    "no providers",
+			wantErr: ErrTokenNotFound,
+		},
+		{
+			name: "first provider with a token",
+			providers: []Provider{
+				&fakeProvider{name: "env", err: ErrTokenNotFound},
+				&fakeProvider{name: "command", token: "command-token"},
+				&fakeProvider{name: "config", token: "config-token"},
+			},
+			wantToken:  "command-token",
+			wantSource: "command",
+		},
+		{
+			name: "provider error",
+			providers: []Provider{
+				&fakeProvider{name: "command", err: unknownErr},
+				&fakeProvider{name: "config", token: "config-token"},
+			},
+			wantErr: unknownErr,
+		},
+		{
+			name: "no provider with a token",
+			providers: []Provider{
+				&fakeProvider{name: "env", err: ErrTokenNotFound},
+				&fakeProvider{name: "config", err: ErrTokenNotFound},
+			},
+			wantErr: ErrTokenNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := NewChainProvider(tt.providers...)
+			token, err := chain.Retrieve()
+			assert.ErrorIs(err, tt.wantErr)
+			assert.Equal(tt.wantToken, token)
+			assert.Equal(tt.wantSource, chain.Source())
+
+			// tokens are only resolved once
+			_, _ = chain.Retrieve()
+			for _, p := range tt.providers {
+				assert.LessOrEqual(p.(*fakeProvider).calls, 1)
+			}
+		})
+	}
+}
+
+func TestNewProviderFromConfig(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	t.Setenv("ACKDEV_TEST_TOKEN", "env-token")
+
+	_, err := NewProviderFromConfig(&config.GithubConfig{
+		Credentials: config.CredentialsConfig{Sources: []string{"vault"}},
+	})
+	assert.ErrorIs(err, ErrUnknownSourceName)
+
+	// default sources
+	chain, err := NewProviderFromConfig(&config.GithubConfig{Token: "config-token"})
+	require.NoError(err)
+	assert.Equal("config,env", chain.Name())
+	token, err := chain.Retrieve()
+	require.NoError(err)
+	assert.Equal("config-token", token)
+
+	chain, err = NewProviderFromConfig(&config.GithubConfig{
+		Token: "config-token",
+		Credentials: config.CredentialsConfig{
+			Sources:     []string{SourceEnv, SourceConfig},
+			EnvVariable: "ACKDEV_TEST_TOKEN",
+		},
+	})
+	require.NoError(err)
+	token, err = chain.Retrieve()
+	require.NoError(err)
+	assert.Equal("env-token", token)
+	assert.Equal(SourceEnv, chain.Source())
+}
+
+func TestCommandProvider_Retrieve(t *testing.T) {
+	assert := assert.New(t)
+
+	token, err := (&CommandProvider{Command: "go env GOOS"}).Retrieve()
+	assert.NoError(err)
+	assert.Equal(runtime.GOOS, token)
+
+	_, err = (&CommandProvider{Command: "ackdev-this-binary-does-not-exist token"}).Retrieve()
+	assert.ErrorIs(err, ErrTokenNotFound)
+
+	_, err = (&CommandProvider{Command: "go this-is-not-a-go-command"}).Retrieve()
+	assert.Error(err)
+	assert.NotErrorIs(err, ErrTokenNotFound)
+}
+
+func Test_parseGitCredentialPassword(t *testing.T) {
+	assert := assert.New(t)
+
+	password, err := parseGitCredentialPassword([]byte("protocol=https\nhost=github.com\nusername=ack-bot\npassword=s3cr3t\n"))
+	assert.NoError(err)
+	assert.Equal("s3cr3t", password)
+
+	_, err = parseGitCredentialPassword([]byte("protocol=https\nhost=github.com\n"))
+	assert.ErrorIs(err, ErrTokenNotFound)
+}
@@ -15,15 +15,24 @@ package git
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 
 	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	"golang.org/x/crypto/ssh"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/credentials"
 )
 
-var _ OpenCloner = &Git{}
+var (
+	_ OpenCloner  = &Git{}
+	_ FetchPusher = &Git{}
+)
 
 const (
 	defaultUser = "git"
@@ -53,6 +62,35 @@ type OpenCloner interface {
 	Cloner
 }
 
+// Fetcher is the interface that wraps the Fetch method.
+//
+// Fetch fetches the references of a remote into a local repository.
+type Fetcher interface {
+	Fetch(
+		ctx context.Context,
+		repo *git.Repository,
+		remote string,
+	) error
+}
+
+// Pusher is the interface that wraps the Push method.
+//
+// Push pushes local references to a remote.
+type Pusher interface {
+	Push(
+		ctx context.Context,
+		repo *git.Repository,
+		remote string,
+		refSpecs ...gitconfig.RefSpec,
+	) error
+}
+
+// FetchPusher is the interface that wraps the Fetch and Push methods.
+type FetchPusher interface {
+	Fetcher
+	Pusher
+}
+
 // New instanciate a new Git struct. It take a list of Option objects
 // to configure the remote and/or the authentication method.
 func New(options ...Option) *Git {
@@ -68,28 +106,25 @@ func New(options ...Option) *Git {
 // mechanisms used to clone repositories.
 // Git implements OpenCloner interface.
 type Git struct {
-	signer         ssh.Signer
-	remote         string
-	githubToken    string
-	githubUsername string
+	signer              ssh.Signer
+	signerLoader        func() (ssh.Signer, error)
+	loadSignerOnce      sync.Once
+	loadSignerErr       error
+	sshAgent            bool
+	knownHostsFiles     []string
+	remote              string
+	githubTokenProvider credentials.Provider
+	githubUsername      string
 }
 
 // Clone clones a remote git repository into a destination path. Clone will
 // prioritise SSH signer if it's set.
 func (g *Git) Clone(ctx context.Context, url, dest string) error {
-	var auth transport.AuthMethod
-	if g.signer != nil {
-		auth = &gitssh.PublicKeys{
-			User:   defaultUser,
-			Signer: g.signer,
-		}
-	} else {
-		auth = &githttp.BasicAuth{
-			Password: g.githubToken,
-			Username: g.githubUsername,
-		}
+	auth, err := g.authMethod()
+	if err != nil {
+		return err
 	}
-	_, err := git.PlainCloneContext(ctx, dest, false, &git.CloneOptions{
+	_, err = git.PlainCloneContext(ctx, dest, false, &git.CloneOptions{
 		Auth:       auth,
 		URL:        url,
 		RemoteName: g.remote,
@@ -101,6 +136,104 @@ func (g *Git) Clone(ctx context.Context, url, dest string) error {
 	return nil
 }
 
+// Fetch fetches the references of a remote into a local repository. It doesn't
+// return an error if the local repository is already up to date.
+func (g *Git) Fetch(ctx context.Context, repo *git.Repository, remote string) error {
+	auth, err := g.authMethod()
+	if err != nil {
+		return err
+	}
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		Auth:       auth,
+		RemoteName: remote,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return err
+	}
+	return nil
+}
+
+// Push pushes the given refspecs to a remote. It doesn't return an error if
+// the remote is already up to date.
+func (g *Git) Push(ctx context.Context, repo *git.Repository, remote string, refSpecs ...gitconfig.RefSpec) error {
+	auth, err := g.authMethod()
+	if err != nil {
+		return err
+	}
+	err = repo.PushContext(ctx, &git.PushOptions{
+		Auth:       auth,
+		RemoteName: remote,
+		RefSpecs:   refSpecs,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return err
+	}
+	return nil
+}
+
+// authMethod returns the authentication method used to interact with
+// remotes. SSH is prioritised if a signer or the ssh-agent is configured.
+// SSH host keys are verified against the known_hosts files.
+func (g *Git) authMethod() (transport.AuthMethod, error) {
+	signer, err := g.getSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	if signer != nil || g.sshAgent {
+		hostKeyCallback, err := gitssh.NewKnownHostsCallback(g.knownHostsFiles...)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load ssh known hosts: %v", err)
+		}
+		hostKeyCallbackHelper := gitssh.HostKeyCallbackHelper{
+			HostKeyCallback: hostKeyCallback,
+		}
+
+		if signer != nil {
+			return &gitssh.PublicKeys{
+				User:                  defaultUser,
+				Signer:                signer,
+				HostKeyCallbackHelper: hostKeyCallbackHelper,
+			}, nil
+		}
+
+		auth, err := gitssh.NewSSHAgentAuth(defaultUser)
+		if err != nil {
+			return nil, fmt.Errorf("cannot use ssh-agent authentication: %v", err)
+		}
+		auth.HostKeyCallbackHelper = hostKeyCallbackHelper
+		return auth, nil
+	}
+
+	if g.githubTokenProvider == nil {
+		return nil, nil
+	}
+	token, err := g.githubTokenProvider.Retrieve()
+	// Public repositories can still be cloned anonymously
+	if errors.Is(err, credentials.ErrTokenNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &githttp.BasicAuth{
+		Password: token,
+		Username: g.githubUsername,
+	}, nil
+}
+
+// getSigner returns the configured ssh.Signer. If the signer is set with a
+// loader, it is only loaded once, the first time it's needed.
+func (g *Git) getSigner() (ssh.Signer, error) {
+	g.loadSignerOnce.Do(func() {
+		if g.signer != nil || g.signerLoader == nil {
+			return
+		}
+		g.signer, g.loadSignerErr = g.signerLoader()
+	})
+	return g.signer, g.loadSignerErr
+}
+
 // Open opens a git repository from the given path.
 func (g *Git) Open(path string) (*git.Repository, error) {
 	return git.PlainOpen(path)
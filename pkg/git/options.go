@@ -13,7 +13,14 @@
 
 package git
 
-import "golang.org/x/crypto/ssh"
+import (
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/credentials"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/util"
+)
 
 type Option func(*Git)
 
@@ -24,12 +31,13 @@ func WithRemote(remote string) Option {
 	}
 }
 
-// WithGithubCredentials sets the Github username and password used to clone
-// repositories with HTTPS protocol.
-func WithGithubCredentials(username, token string) Option {
+// WithGithubCredentials sets the Github username and the provider of the token used
+// to clone repositories with HTTPS protocol. The token is retrieved the first time
+// it's needed.
+func WithGithubCredentials(username string, provider credentials.Provider) Option {
 	return func(g *Git) {
 		g.githubUsername = username
-		g.githubToken = token
+		g.githubTokenProvider = provider
 	}
 }
 
@@ -40,3 +48,35 @@ func WithSSHSigner(signer ssh.Signer) Option {
 		g.signer = signer
 	}
 }
+
+// WithSSHKeyPath sets the path of the private key used to clone repositories with
+// ssh protocol. The key is only loaded when it's needed for the first time, which
+// means that users are prompted for encrypted keys passphrase only once and only
+// if a remote is contacted.
+func WithSSHKeyPath(sshKeyPath string) Option {
+	return func(g *Git) {
+		g.signerLoader = func() (ssh.Signer, error) {
+			signer, err := util.NewSigner(sshKeyPath)
+			if err != nil {
+				return nil, fmt.Errorf("cannot load ssh key %s: %v", sshKeyPath, err)
+			}
+			return signer, nil
+		}
+	}
+}
+
+// WithSSHAgent configures the git client to authenticate using the ssh-agent
+// listening on SSH_AUTH_SOCK. A configured ssh signer always takes precedence.
+func WithSSHAgent() Option {
+	return func(g *Git) {
+		g.sshAgent = true
+	}
+}
+
+// WithKnownHostsFiles sets the known_hosts files used to verify the remotes host
+// keys. If no files are given, $SSH_KNOWN_HOSTS or ~/.ssh/known_hosts is used.
+func WithKnownHostsFiles(files ...string) Option {
+	return func(g *Git) {
+		g.knownHostsFiles = files
+	}
+}
@@ -16,10 +16,18 @@ package github
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-github/v61/github"
 	"golang.org/x/oauth2"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/credentials"
 )
 
 var _ RepositoryService = &Client{}
@@ -27,61 +35,195 @@ var _ RepositoryService = &Client{}
 var ErrForkNotFound = errors.New("fork not found")
 
 const (
-	ACKOrg                = "aws-controllers-k8s"
+	ACKOrg = config.DefaultUpstreamOrganization
+	// defaultRequestTimeout is the timeout of a single request attempt.
 	defaultRequestTimeout = 10 * time.Second
 )
 
-// NewClient takes a token and instantiate a new Client object
-func NewClient(token string) *Client {
-	ctx := context.TODO()
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	oc := oauth2.NewClient(ctx, ts)
-	return &Client{github.NewClient(oc)}
+// IsNotFound returns true if err is a Github API 404 error.
+func IsNotFound(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil &&
+		errResp.Response.StatusCode == http.StatusNotFound
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithOrganization sets the organization owning the upstream repositories.
+// Defaults to ACKOrg.
+func WithOrganization(org string) Option {
+	return func(c *Client) {
+		c.org = org
+	}
+}
+
+// WithForkOrganization sets the organization in which the upstream repositories
+// are forked. Defaults to the authenticated user account.
+func WithForkOrganization(org string) Option {
+	return func(c *Client) {
+		c.forkOrg = org
+	}
+}
+
+// WithEnterpriseURL sets the Github Enterprise API base URL.
+// e.g https://github.example.com/api/v3/
+func WithEnterpriseURL(apiURL string) Option {
+	return func(c *Client) {
+		c.enterpriseURL = apiURL
+	}
+}
+
+// WithMaxRetries sets the maximum number of times a request failing with a
+// transient error is retried. Defaults to 3.
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *Client) {
+		c.transport.maxRetries = maxRetries
+	}
+}
+
+// WithMaxRateLimitWait sets the maximum duration to wait for a Github rate
+// limit to reset. Requests hitting a rate limit resetting later fail with a
+// RateLimitExceededError. Defaults to 1 minute.
+func WithMaxRateLimitWait(wait time.Duration) Option {
+	return func(c *Client) {
+		c.transport.maxRateLimitWait = wait
+	}
+}
+
+// NewClient takes a credentials provider and a list of options and instantiate a
+// new Client object. The token is only retrieved from the provider when the first
+// request is made.
+func NewClient(provider credentials.Provider, options ...Option) (*Client, error) {
+	c := &Client{
+		org: ACKOrg,
+		transport: &retryTransport{
+			requestTimeout:   defaultRequestTimeout,
+			maxRetries:       defaultMaxRetries,
+			maxRateLimitWait: defaultMaxRateLimitWait,
+			retryInterval:    initialRetryInterval,
+		},
+	}
+	for _, option := range options {
+		option(c)
+	}
+
+	c.transport.next = &oauth2.Transport{
+		Source: oauth2.ReuseTokenSource(nil, &tokenSource{provider}),
+		Base:   http.DefaultTransport,
+	}
+	c.Client = github.NewClient(&http.Client{Transport: c.transport})
+	if c.enterpriseURL != "" {
+		client, err := c.Client.WithEnterpriseURLs(c.enterpriseURL, c.enterpriseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Github Enterprise URL %s: %v", c.enterpriseURL, err)
+		}
+		c.Client = client
+	}
+	return c, nil
+}
+
+// NewClientFromConfig instantiates a new Client using the Github configuration
+// and the given credentials provider.
+func NewClientFromConfig(provider credentials.Provider, cfg *config.GithubConfig) (*Client, error) {
+	options := []Option{
+		WithOrganization(cfg.GetOrganization()),
+		WithForkOrganization(cfg.ForkOrganization),
+	}
+	if cfg.APIURL != "" {
+		options = append(options, WithEnterpriseURL(cfg.APIURL))
+	}
+	return NewClient(provider, options...)
+}
+
+// tokenSource is an oauth2.TokenSource retrieving tokens from a credentials
+// provider.
+type tokenSource struct {
+	provider credentials.Provider
+}
+
+// Token implements oauth2.TokenSource.Token
+func (ts *tokenSource) Token() (*oauth2.Token, error) {
+	token, err := ts.provider.Retrieve()
+	if err != nil {
+		return nil, err
+	}
+	return &oauth2.Token{AccessToken: token}, nil
 }
 
 // RepositoryService is the interface implemented by the Github client wrapper. It exposes
 // functionalities to simplify the interactions with the repository endpoint of Github APIv3
 type RepositoryService interface {
-	ForkRepository(ctx context.Context, repoName string) error
+	ForkRepository(ctx context.Context, repoName, forkName string) (*github.Repository, error)
 	RenameRepository(ctx context.Context, owner, name, newName string) error
 	GetRepository(ctx context.Context, owner, repoName string) (*github.Repository, error)
 	ListRepositoryForks(ctx context.Context, repoName string) ([]*github.Repository, error)
-	GetUserRepositoryFork(ctx context.Context, owner, repoName string) (*github.Repository, error)
+	GetUserRepositoryFork(ctx context.Context, owner, repoName, forkName string) (*github.Repository, error)
+	CreatePullRequest(ctx context.Context, repoName string, pr *github.NewPullRequest) (*github.PullRequest, error)
+	RequestReviewers(ctx context.Context, repoName string, number int, reviewers []string) error
+	ListPullRequests(ctx context.Context, repoName, author string) ([]*github.PullRequest, error)
+	GetPullRequest(ctx context.Context, repoName string, number int) (*github.PullRequest, error)
+	ListPullRequestReviews(ctx context.Context, repoName string, number int) ([]*github.PullRequestReview, error)
+	GetCombinedStatus(ctx context.Context, repoName, ref string) (*github.CombinedStatus, error)
+	ListCheckRuns(ctx context.Context, repoName, ref string) ([]*github.CheckRun, error)
 }
 
-// Client is a github.Client wrapper
+// Client is a github.Client wrapper. All the repositories it interacts with
+// belong to the upstream organization.
 type Client struct {
 	*github.Client
+
+	org           string
+	forkOrg       string
+	enterpriseURL string
+	transport     *retryTransport
+
+	// login is the authenticated user login, see authenticatedLogin
+	login      string
+	loginMutex sync.Mutex
 }
 
-// ForkRepository forks a Github repository from the ACK organisation.
-func (c *Client) ForkRepository(ctx context.Context, repoName string) error {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
+// GetRateLimits returns the rate limits and the remaining quota of the
+// authenticated user.
+func (c *Client) GetRateLimits(ctx context.Context) (*github.RateLimits, error) {
+	limits, _, err := c.Client.RateLimit.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
 
-	opt := &github.RepositoryCreateForkOptions{}
-	_, _, err := c.Client.Repositories.CreateFork(ctx, ACKOrg, repoName, opt)
+// ForkRepository forks a Github repository from the upstream organisation and names
+// the fork forkName. Fork creation is asynchronous, the returned fork might not be
+// accessible yet. Github instances that don't support naming forks ignore forkName,
+// the returned fork name should be checked. The returned fork is nil if Github
+// didn't return any information about it.
+func (c *Client) ForkRepository(ctx context.Context, repoName, forkName string) (*github.Repository, error) {
+	opt := &github.RepositoryCreateForkOptions{
+		Organization: c.forkOrg,
+		Name:         forkName,
+	}
+	fork, resp, err := c.Client.Repositories.CreateFork(ctx, c.org, repoName, opt)
 	if err != nil {
-		// AcceptedError occurs when GitHub returns 202 Accepted response with an
-		// empty body, which means a job was scheduled on the GitHub side to process
-		// the information needed and cache it.
+		// AcceptedError occurs when GitHub returns 202 Accepted response,
+		// which means a job was scheduled on the GitHub side to create the fork.
+		// The response body generally contains the future fork information,
+		// when it's empty go-github fails to decode it.
 		// https://github.com/google/go-github/blob/master/github/github.go#L699-L704
-		if _, ok := err.(*github.AcceptedError); ok {
-			return nil
+		if _, ok := err.(*github.AcceptedError); ok && fork.GetName() != "" {
+			return fork, nil
 		}
-		return err
+		if resp != nil && resp.StatusCode == http.StatusAccepted {
+			return nil, nil
+		}
+		return nil, err
 	}
-	return nil
+	return fork, nil
 }
 
 // RenameRepository renames a Github repository. The request should have admin access on the
 // target repositories to be able to rename it.
 func (c *Client) RenameRepository(ctx context.Context, owner, name, newName string) error {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
-
 	opt := &github.Repository{
 		Name: &newName,
 	}
@@ -94,9 +236,6 @@ func (c *Client) RenameRepository(ctx context.Context, owner, name, newName stri
 
 // GetRepository takes an owner and repoName and returns the Github repository informations
 func (c *Client) GetRepository(ctx context.Context, owner, repoName string) (*github.Repository, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
-
 	repo, _, err := c.Client.Repositories.Get(ctx, owner, repoName)
 	if err != nil {
 		return nil, err
@@ -104,12 +243,9 @@ func (c *Client) GetRepository(ctx context.Context, owner, repoName string) (*gi
 	return repo, nil
 }
 
-// ListRepositoryForks list the forks of a given repository in the ACK organisation. It returns
+// ListRepositoryForks list the forks of a given repository in the upstream organisation. It returns
 // a list fork information which includes the owner and the fork name (forkInfo).
 func (c *Client) ListRepositoryForks(ctx context.Context, repoName string) ([]*github.Repository, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
-
 	var forks []*github.Repository
 	var err error
 	var repos []*github.Repository
@@ -129,7 +265,7 @@ func (c *Client) ListRepositoryForks(ctx context.Context, repoName string) ([]*g
 			},
 		}
 
-		repos, resp, err = c.Client.Repositories.ListForks(ctx, ACKOrg, repoName, opt)
+		repos, resp, err = c.Client.Repositories.ListForks(ctx, c.org, repoName, opt)
 		if err != nil {
 			return nil, err
 		}
@@ -140,20 +276,269 @@ func (c *Client) ListRepositoryForks(ctx context.Context, repoName string) ([]*g
 	return forks, nil
 }
 
-// GetUserRepositoryFork takes an ACK repository name and tries to find it fork in the user public repositories.
-func (c *Client) GetUserRepositoryFork(ctx context.Context, owner string, repoName string) (*github.Repository, error) {
-	ctx, cancel := context.WithTimeout(ctx, defaultRequestTimeout)
-	defer cancel()
+// GetUserRepositoryFork takes an ACK repository name and tries to find its fork in the
+// owner (user or organization) repositories. It first looks for a repository named
+// forkName, then falls back to the owner forks, including the private ones the
+// authenticated user can access, to find renamed forks. Forks whose name contains the
+// ACK repository name are checked first. A repository is only considered a fork if its
+// parent is the ACK repository.
+func (c *Client) GetUserRepositoryFork(ctx context.Context, owner, repoName, forkName string) (*github.Repository, error) {
+	repo, err := c.GetRepository(ctx, owner, forkName)
+	if err == nil && c.isUpstreamFork(repo, repoName) {
+		return repo, nil
+	}
+	if err != nil && !IsNotFound(err) {
+		return nil, err
+	}
 
-	repos, err := c.ListRepositoryForks(ctx, repoName)
+	forks, err := c.listOwnerForks(ctx, owner)
 	if err != nil {
 		return nil, err
 	}
+	candidates := []*github.Repository{}
+	for _, fork := range forks {
+		if fork.GetName() != forkName {
+			candidates = append(candidates, fork)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return strings.Contains(candidates[i].GetName(), repoName) &&
+			!strings.Contains(candidates[j].GetName(), repoName)
+	})
 
-	for _, repo := range repos {
-		if *repo.Owner.Login == owner {
-			return repo, nil
+	for _, candidate := range candidates {
+		// Listed repositories don't contain their parent information
+		fork, err := c.GetRepository(ctx, owner, candidate.GetName())
+		if IsNotFound(err) {
+			// deleted since it was listed
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if c.isUpstreamFork(fork, repoName) {
+			return fork, nil
 		}
 	}
 	return nil, ErrForkNotFound
 }
+
+// listOwnerForks returns the forks owned by a user or an organization. The
+// private forks are listed for the authenticated user and the organizations it
+// belongs to; other users private forks aren't accessible anyway.
+func (c *Client) listOwnerForks(ctx context.Context, owner string) ([]*github.Repository, error) {
+	login, err := c.authenticatedLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	isAuthenticatedUser := strings.EqualFold(owner, login)
+	isOrganization := false
+	if !isAuthenticatedUser {
+		user, _, err := c.Client.Users.Get(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+		isOrganization = user.GetType() == "Organization"
+	}
+
+	var forks, repos []*github.Repository
+	var resp *github.Response = &github.Response{
+		// FirstPage is always of index 1
+		NextPage: 1,
+	}
+
+	// iterate over all the pages
+	for resp.NextPage != 0 {
+		listOptions := github.ListOptions{
+			Page:    resp.NextPage,
+			PerPage: 100,
+		}
+		switch {
+		case isAuthenticatedUser:
+			repos, resp, err = c.Client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{
+				Affiliation: "owner",
+				ListOptions: listOptions,
+			})
+		case isOrganization:
+			repos, resp, err = c.Client.Repositories.ListByOrg(ctx, owner, &github.RepositoryListByOrgOptions{
+				Type:        "forks",
+				ListOptions: listOptions,
+			})
+		default:
+			repos, resp, err = c.Client.Repositories.ListByUser(ctx, owner, &github.RepositoryListByUserOptions{
+				Type:        "owner",
+				ListOptions: listOptions,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, repo := range repos {
+			if repo.GetFork() {
+				forks = append(forks, repo)
+			}
+		}
+	}
+	return forks, nil
+}
+
+// authenticatedLogin returns the login of the authenticated user.
+func (c *Client) authenticatedLogin(ctx context.Context) (string, error) {
+	c.loginMutex.Lock()
+	defer c.loginMutex.Unlock()
+	if c.login != "" {
+		return c.login, nil
+	}
+	user, _, err := c.Client.Users.Get(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	c.login = user.GetLogin()
+	return c.login, nil
+}
+
+// isUpstreamFork returns true if repo is a fork of the repoName repository of
+// the upstream organization.
+func (c *Client) isUpstreamFork(repo *github.Repository, repoName string) bool {
+	parent := repo.GetParent()
+	return repo.GetFork() && parent != nil &&
+		strings.EqualFold(parent.GetOwner().GetLogin(), c.org) &&
+		parent.GetName() == repoName
+}
+
+// CreatePullRequest opens a pull request against a repository of the upstream organisation.
+// To open a pull request from a fork, the head should be formatted as 'owner:branch'.
+func (c *Client) CreatePullRequest(ctx context.Context, repoName string, pr *github.NewPullRequest) (*github.PullRequest, error) {
+	pullRequest, _, err := c.Client.PullRequests.Create(ctx, c.org, repoName, pr)
+	if err != nil {
+		return nil, err
+	}
+	return pullRequest, nil
+}
+
+// RequestReviewers requests reviews from a list of Github users on a pull request
+// of an upstream organisation repository.
+func (c *Client) RequestReviewers(ctx context.Context, repoName string, number int, reviewers []string) error {
+	_, _, err := c.Client.PullRequests.RequestReviewers(ctx, c.org, repoName, number, github.ReviewersRequest{
+		Reviewers: reviewers,
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListPullRequests lists the open pull requests of an upstream organisation repository. If
+// author is not empty, only the pull requests opened by the author are returned.
+func (c *Client) ListPullRequests(ctx context.Context, repoName, author string) ([]*github.PullRequest, error) {
+	var pullRequests []*github.PullRequest
+	var err error
+	var prs []*github.PullRequest
+	var resp *github.Response = &github.Response{
+		// FirstPage is always of index 1
+		NextPage: 1,
+	}
+
+	// iterate over all the pages
+	for resp.NextPage != 0 {
+		opt := &github.PullRequestListOptions{
+			State: "open",
+			ListOptions: github.ListOptions{
+				Page:    resp.NextPage,
+				PerPage: 100,
+			},
+		}
+
+		prs, resp, err = c.Client.PullRequests.List(ctx, c.org, repoName, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range prs {
+			if author == "" || pr.GetUser().GetLogin() == author {
+				pullRequests = append(pullRequests, pr)
+			}
+		}
+	}
+
+	return pullRequests, nil
+}
+
+// GetPullRequest returns a pull request of an upstream organisation repository. Unlike
+// ListPullRequests, the returned pull request contains the mergeability information.
+func (c *Client) GetPullRequest(ctx context.Context, repoName string, number int) (*github.PullRequest, error) {
+	pr, _, err := c.Client.PullRequests.Get(ctx, c.org, repoName, number)
+	if err != nil {
+		return nil, err
+	}
+	return pr, nil
+}
+
+// ListPullRequestReviews lists the reviews of a pull request in chronological order.
+func (c *Client) ListPullRequestReviews(ctx context.Context, repoName string, number int) ([]*github.PullRequestReview, error) {
+	var reviews []*github.PullRequestReview
+	var err error
+	var page []*github.PullRequestReview
+	var resp *github.Response = &github.Response{
+		// FirstPage is always of index 1
+		NextPage: 1,
+	}
+
+	// iterate over all the pages
+	for resp.NextPage != 0 {
+		opt := &github.ListOptions{
+			Page:    resp.NextPage,
+			PerPage: 100,
+		}
+
+		page, resp, err = c.Client.PullRequests.ListReviews(ctx, c.org, repoName, number, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, page...)
+	}
+
+	return reviews, nil
+}
+
+// GetCombinedStatus returns the combined commit status of a git reference. Prow
+// reports the ACK presubmit jobs results using commit statuses.
+func (c *Client) GetCombinedStatus(ctx context.Context, repoName, ref string) (*github.CombinedStatus, error) {
+	status, _, err := c.Client.Repositories.GetCombinedStatus(ctx, c.org, repoName, ref, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// ListCheckRuns lists the check runs (Github actions, apps...) of a git reference.
+func (c *Client) ListCheckRuns(ctx context.Context, repoName, ref string) ([]*github.CheckRun, error) {
+	var checkRuns []*github.CheckRun
+	var err error
+	var results *github.ListCheckRunsResults
+	var resp *github.Response = &github.Response{
+		// FirstPage is always of index 1
+		NextPage: 1,
+	}
+
+	// iterate over all the pages
+	for resp.NextPage != 0 {
+		opt := &github.ListCheckRunsOptions{
+			ListOptions: github.ListOptions{
+				Page:    resp.NextPage,
+				PerPage: 100,
+			},
+		}
+
+		results, resp, err = c.Client.Checks.ListCheckRunsForRef(ctx, c.org, repoName, ref, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		checkRuns = append(checkRuns, results.CheckRuns...)
+	}
+
+	return checkRuns, nil
+}
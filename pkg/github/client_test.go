@@ -0,0 +1,252 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-github/v61/github"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/credentials"
+)
+
+func TestNewClient(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	provider := &credentials.StaticProvider{Token: "token"}
+
+	c, err := NewClient(provider)
+	require.NoError(err)
+	assert.Equal(ACKOrg, c.org)
+	assert.Equal("https://api.github.com/", c.BaseURL.String())
+
+	c, err = NewClient(provider,
+		WithOrganization("ack-mirror"),
+		WithEnterpriseURL("https://github.example.com"),
+	)
+	require.NoError(err)
+	assert.Equal("ack-mirror", c.org)
+	assert.Equal("https://github.example.com/api/v3/", c.BaseURL.String())
+	assert.Equal("https://github.example.com/api/uploads/", c.UploadURL.String())
+
+	_, err = NewClient(provider, WithEnterpriseURL("://invalid"))
+	assert.Error(err)
+}
+
+func TestClient_ForkRepository(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantFork string
+		wantErr  bool
+	}{
+		{
+			name:     "fork created",
+			status:   http.StatusAccepted,
+			body:     `{"name": "ack-s3-controller"}`,
+			wantFork: "ack-s3-controller",
+		},
+		{
+			name:   "fork scheduled without body",
+			status: http.StatusAccepted,
+		},
+		{
+			name:    "upstream repository not found",
+			status:  http.StatusNotFound,
+			body:    `{"message": "Not Found"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal("/api/v3/repos/aws-controllers-k8s/s3-controller/forks", r.URL.Path)
+			w.WriteHeader(tt.status)
+			fmt.Fprint(w, tt.body)
+		}))
+
+		c, err := NewClient(&credentials.StaticProvider{Token: "token"}, WithEnterpriseURL(server.URL))
+		require.NoError(err)
+
+		fork, err := c.ForkRepository(context.TODO(), "s3-controller", "ack-s3-controller")
+		server.Close()
+		if tt.wantErr {
+			assert.Error(err, tt.name)
+			assert.True(IsNotFound(err), tt.name)
+			continue
+		}
+		require.NoError(err, tt.name)
+		if tt.wantFork == "" {
+			assert.Nil(fork, tt.name)
+		} else {
+			assert.Equal(tt.wantFork, fork.GetName(), tt.name)
+		}
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsNotFound(&github.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusNotFound},
+	}))
+	assert.False(IsNotFound(&github.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusForbidden},
+	}))
+	assert.False(IsNotFound(&github.ErrorResponse{}))
+	assert.False(IsNotFound(errors.New("not found")))
+	assert.False(IsNotFound(nil))
+}
+
+func TestClient_GetUserRepositoryFork(t *testing.T) {
+	upstream := `{"name": "s3-controller", "owner": {"login": "aws-controllers-k8s"}}`
+	otherUpstream := `{"name": "s3-controller", "owner": {"login": "someone"}}`
+	runtimeUpstream := `{"name": "runtime", "owner": {"login": "aws-controllers-k8s"}}`
+	repository := func(name, parent string) string {
+		return fmt.Sprintf(`{"name": %q, "fork": true, "owner": {"login": "ack-bot"}, "parent": %s}`, name, parent)
+	}
+
+	tests := []struct {
+		name string
+		// owner is the fork owner, defaults to the authenticated user
+		owner string
+		// routes maps request paths to response bodies, other paths return 404
+		routes   map[string]string
+		wantFork string
+		wantErr  error
+	}{
+		{
+			name: "expected fork found",
+			routes: map[string]string{
+				"/api/v3/repos/ack-bot/ack-s3-controller": repository("ack-s3-controller", upstream),
+			},
+			wantFork: "ack-s3-controller",
+		},
+		{
+			name:  "renamed fork found in user repositories",
+			owner: "ack-bot",
+			routes: map[string]string{
+				"/api/v3/user/repos": `[
+					{"name": "runtime", "fork": true},
+					{"name": "ack-s3-controller-old", "fork": true},
+					{"name": "s3-controller-tests", "fork": false}
+				]`,
+				"/api/v3/repos/ack-bot/runtime":               repository("runtime", runtimeUpstream),
+				"/api/v3/repos/ack-bot/ack-s3-controller-old": repository("ack-s3-controller-old", upstream),
+			},
+			wantFork: "ack-s3-controller-old",
+		},
+		{
+			name:  "fork renamed without the repository name",
+			owner: "ack-bot",
+			routes: map[string]string{
+				"/api/v3/user/repos": `[
+					{"name": "storage", "fork": true},
+					{"name": "deleted", "fork": true}
+				]`,
+				"/api/v3/repos/ack-bot/storage": repository("storage", upstream),
+			},
+			wantFork: "storage",
+		},
+		{
+			name:  "renamed fork found in another user public repositories",
+			owner: "someone",
+			routes: map[string]string{
+				"/api/v3/users/someone":                       `{"login": "someone", "type": "User"}`,
+				"/api/v3/users/someone/repos":                 `[{"name": "ack-s3-controller-old", "fork": true}]`,
+				"/api/v3/repos/someone/ack-s3-controller-old": repository("ack-s3-controller-old", upstream),
+			},
+			wantFork: "ack-s3-controller-old",
+		},
+		{
+			name:  "renamed fork found in organization repositories",
+			owner: "ack-org",
+			routes: map[string]string{
+				"/api/v3/users/ack-org":      `{"login": "ack-org", "type": "Organization"}`,
+				"/api/v3/orgs/ack-org/repos": `[{"name": "s3", "fork": true, "private": true}]`,
+				"/api/v3/repos/ack-org/s3":   repository("s3", upstream),
+			},
+			wantFork: "s3",
+		},
+		{
+			name: "expected fork with another parent",
+			routes: map[string]string{
+				"/api/v3/repos/ack-bot/ack-s3-controller": repository("ack-s3-controller", otherUpstream),
+				"/api/v3/user/repos":                      `[{"name": "ack-s3-controller", "fork": true}]`,
+			},
+			wantErr: ErrForkNotFound,
+		},
+		{
+			name: "fork not found",
+			routes: map[string]string{
+				"/api/v3/user/repos": `[]`,
+			},
+			wantErr: ErrForkNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v3/user" {
+					fmt.Fprint(w, `{"login": "ack-bot"}`)
+					return
+				}
+				switch r.URL.Path {
+				case "/api/v3/user/repos":
+					assert.Equal("owner", r.URL.Query().Get("affiliation"))
+				case "/api/v3/orgs/ack-org/repos":
+					assert.Equal("forks", r.URL.Query().Get("type"))
+				}
+				body, ok := tt.routes[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"message": "Not Found"}`)
+					return
+				}
+				fmt.Fprint(w, body)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(&credentials.StaticProvider{Token: "token"}, WithEnterpriseURL(server.URL))
+			require.NoError(err)
+
+			owner := tt.owner
+			if owner == "" {
+				owner = "ack-bot"
+			}
+			fork, err := c.GetUserRepositoryFork(context.TODO(), owner, "s3-controller", "ack-s3-controller")
+			if tt.wantErr != nil {
+				assert.Equal(tt.wantErr, err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantFork, fork.GetName())
+		})
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/util"
+)
+
+const (
+	defaultMaxRetries       = 3
+	defaultMaxRateLimitWait = 1 * time.Minute
+
+	// defaultSecondaryRateLimitWait is the duration to wait when Github hits a
+	// secondary rate limit without returning a Retry-After header. Github
+	// recommends waiting at least one minute.
+	defaultSecondaryRateLimitWait = 1 * time.Minute
+
+	initialRetryInterval = 500 * time.Millisecond
+	maxRetryInterval     = 10 * time.Second
+
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
+)
+
+// RateLimitExceededError is returned when a Github rate limit is hit and the time
+// to wait before it resets exceeds the maximum rate limit wait of the client.
+type RateLimitExceededError struct {
+	// Secondary tells whether the secondary (abuse) rate limit was hit
+	Secondary bool
+	// Reset is the time at which requests can be made again
+	Reset time.Time
+}
+
+// Error implements the error interface
+func (e *RateLimitExceededError) Error() string {
+	kind := "API rate limit"
+	if e.Secondary {
+		kind = "secondary API rate limit"
+	}
+	wait := time.Until(e.Reset).Round(time.Second)
+	return fmt.Sprintf("Github %s exceeded, requests are allowed again at %s (in %s). "+
+		"Run 'ackdev github quota' to check your remaining quota",
+		kind, e.Reset.Local().Format(time.Kitchen), wait)
+}
+
+// IsRateLimited returns true if err was caused by a Github rate limit.
+func IsRateLimited(err error) bool {
+	var rlErr *RateLimitExceededError
+	return errors.As(err, &rlErr)
+}
+
+// retryTransport is an http.RoundTripper retrying the requests that failed with
+// transient errors (timeouts, 5xx responses) using an exponential backoff. Non
+// idempotent requests, e.g pull requests or forks creation, are only retried if
+// they were never sent, so that they aren't applied twice. When a
+// Github rate limit is hit, it waits for the limit to reset if it resets within
+// maxRateLimitWait, otherwise it fails with a RateLimitExceededError.
+type retryTransport struct {
+	next http.RoundTripper
+
+	// requestTimeout is the timeout of each attempt
+	requestTimeout time.Duration
+	// maxRetries is the maximum number of times a request is retried
+	maxRetries int
+	// maxRateLimitWait is the maximum duration to wait for a rate limit reset
+	maxRateLimitWait time.Duration
+	// retryInterval is the initial interval between retries
+	retryInterval time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	backoff := &util.Backoff{Initial: t.retryInterval, Max: maxRetryInterval}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, fmt.Errorf("cannot retry request %s %s: body cannot be rewound", req.Method, req.URL)
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := t.roundTrip(req)
+		if err != nil {
+			if attempt >= t.maxRetries || !isRetryableError(ctx, req, err) {
+				return nil, err
+			}
+			if err := util.Sleep(ctx, backoff.Next()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if wait, secondary, limited := rateLimitWait(resp); limited {
+			if wait > t.maxRateLimitWait {
+				drainAndClose(resp.Body)
+				return nil, &RateLimitExceededError{
+					Secondary: secondary,
+					Reset:     time.Now().Add(wait),
+				}
+			}
+			drainAndClose(resp.Body)
+			if err := util.Sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+			// Waiting for a rate limit reset doesn't count as a retry
+			attempt--
+			continue
+		}
+
+		// The server received the request, it may have been applied
+		if attempt >= t.maxRetries || !isIdempotent(req.Method) || !isTransientStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		drainAndClose(resp.Body)
+		if err := util.Sleep(ctx, backoff.Next()); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// roundTrip sends a single attempt of req, bounded by the request timeout. The
+// timeout covers reading the response body.
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	if t.requestTimeout == 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.requestTimeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// rateLimitWait returns the duration to wait before retrying a rate limited
+// request. limited is false if the response isn't a rate limit error.
+func rateLimitWait(resp *http.Response) (wait time.Duration, secondary bool, limited bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false, false
+	}
+
+	// Secondary rate limits responses contain a Retry-After header
+	// https://docs.github.com/en/rest/overview/rate-limits-for-the-rest-api#exceeding-the-rate-limit
+	if retryAfter := resp.Header.Get(headerRetryAfter); retryAfter != "" {
+		seconds, err := strconv.Atoi(retryAfter)
+		if err == nil {
+			return time.Duration(seconds) * time.Second, true, true
+		}
+	}
+
+	if resp.Header.Get(headerRateLimitRemaining) == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get(headerRateLimitReset), 10, 64)
+		if err != nil {
+			return 0, false, false
+		}
+		wait := time.Until(time.Unix(reset, 0))
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, false, true
+	}
+
+	// Secondary rate limits responses don't always contain a Retry-After header,
+	// the only way to identify them is the error message.
+	if resp.StatusCode == http.StatusForbidden {
+		b, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(strings.NewReader(string(b)))
+		if err == nil && strings.Contains(strings.ToLower(string(b)), "secondary rate limit") {
+			return defaultSecondaryRateLimitWait, true, true
+		}
+	}
+	return 0, false, false
+}
+
+// isTransientStatus returns true if a request that failed with the given status
+// code is worth retrying.
+func isTransientStatus(code int) bool {
+	switch code {
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent returns true if sending a request with the given method twice
+// has the same effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isRetryableError returns true if req, which failed with err, is worth
+// retrying. Non idempotent requests are only retried if they were never sent.
+// Requests are never retried once the parent context is done.
+func isRetryableError(ctx context.Context, req *http.Request, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if isDialError(err) {
+		return true
+	}
+	return isIdempotent(req.Method) && isTransientError(err)
+}
+
+// isTransientError returns true if err is a timeout or an interrupted response.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isDialError returns true if err happened while connecting to the server, i.e
+// before the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(body, 4096))
+	body.Close()
+}
+
+// cancelOnCloseBody cancels the attempt context once the response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer.Close
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
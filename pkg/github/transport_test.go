@@ -0,0 +1,288 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResponse is a response returned by the test server
+type fakeResponse struct {
+	status  int
+	headers map[string]string
+	body    string
+	delay   time.Duration
+}
+
+func newTestServer(responses ...fakeResponse) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(atomic.AddInt32(&calls, 1)) - 1
+		if i >= len(responses) {
+			i = len(responses) - 1
+		}
+		resp := responses[i]
+		time.Sleep(resp.delay)
+		for k, v := range resp.headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(resp.status)
+		fmt.Fprint(w, resp.body)
+	}))
+	return server, &calls
+}
+
+func newTestTransport() *retryTransport {
+	return &retryTransport{
+		next:             http.DefaultTransport,
+		requestTimeout:   100 * time.Millisecond,
+		maxRetries:       2,
+		maxRateLimitWait: time.Second,
+		retryInterval:    time.Millisecond,
+	}
+}
+
+func TestRetryTransport_RoundTrip(t *testing.T) {
+	resetIn := func(d time.Duration) string {
+		return strconv.FormatInt(time.Now().Add(d).Unix(), 10)
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		responses     []fakeResponse
+		wantStatus    int
+		wantCalls     int32
+		wantErr       bool
+		wantRateLimit bool
+	}{
+		{
+			name:       "success",
+			responses:  []fakeResponse{{status: http.StatusOK}},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		{
+			name: "transient errors retried",
+			responses: []fakeResponse{
+				{status: http.StatusBadGateway},
+				{status: http.StatusServiceUnavailable},
+				{status: http.StatusOK},
+			},
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+		{
+			name:       "transient errors not retried for non idempotent requests",
+			method:     http.MethodPost,
+			responses:  []fakeResponse{{status: http.StatusBadGateway}, {status: http.StatusOK}},
+			wantStatus: http.StatusBadGateway,
+			wantCalls:  1,
+		},
+		{
+			name:   "timeout not retried for non idempotent requests",
+			method: http.MethodPatch,
+			responses: []fakeResponse{
+				{status: http.StatusOK, delay: 300 * time.Millisecond},
+				{status: http.StatusOK},
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:       "transient errors exceeding max retries",
+			responses:  []fakeResponse{{status: http.StatusInternalServerError}},
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  3,
+		},
+		{
+			name:       "client errors not retried",
+			responses:  []fakeResponse{{status: http.StatusNotFound}},
+			wantStatus: http.StatusNotFound,
+			wantCalls:  1,
+		},
+		{
+			name: "timeout retried",
+			responses: []fakeResponse{
+				{status: http.StatusOK, delay: 300 * time.Millisecond},
+				{status: http.StatusOK},
+			},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name: "forbidden not rate limited",
+			responses: []fakeResponse{
+				{status: http.StatusForbidden, body: `{"message": "Resource not accessible"}`},
+			},
+			wantStatus: http.StatusForbidden,
+			wantCalls:  1,
+		},
+		{
+			name: "primary rate limit reset soon",
+			responses: []fakeResponse{
+				{
+					status: http.StatusForbidden,
+					headers: map[string]string{
+						headerRateLimitRemaining: "0",
+						headerRateLimitReset:     resetIn(-time.Second),
+					},
+				},
+				{status: http.StatusOK},
+			},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name: "primary rate limit reset too late",
+			responses: []fakeResponse{
+				{
+					status: http.StatusForbidden,
+					headers: map[string]string{
+						headerRateLimitRemaining: "0",
+						headerRateLimitReset:     resetIn(time.Hour),
+					},
+				},
+			},
+			wantCalls:     1,
+			wantErr:       true,
+			wantRateLimit: true,
+		},
+		{
+			name:   "secondary rate limit with retry after",
+			method: http.MethodPost,
+			responses: []fakeResponse{
+				{
+					status:  http.StatusTooManyRequests,
+					headers: map[string]string{headerRetryAfter: "0"},
+				},
+				{status: http.StatusOK},
+			},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name: "secondary rate limit without retry after",
+			responses: []fakeResponse{
+				{
+					status: http.StatusForbidden,
+					body:   `{"message": "You have exceeded a secondary rate limit."}`,
+				},
+			},
+			wantCalls:     1,
+			wantErr:       true,
+			wantRateLimit: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			server, calls := newTestServer(tt.responses...)
+			defer server.Close()
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPut
+			}
+			req, err := http.NewRequest(method, server.URL, strings.NewReader("body"))
+			require.NoError(err)
+			resp, err := newTestTransport().RoundTrip(req)
+			assert.Equal(tt.wantCalls, atomic.LoadInt32(calls))
+			if tt.wantErr {
+				require.Error(err)
+				assert.Equal(tt.wantRateLimit, IsRateLimited(err))
+				return
+			}
+			require.NoError(err)
+			defer resp.Body.Close()
+			assert.Equal(tt.wantStatus, resp.StatusCode)
+			_, err = ioutil.ReadAll(resp.Body)
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestRetryTransport_RoundTrip_ContextCanceled(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	server, calls := newTestServer(fakeResponse{status: http.StatusBadGateway})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(err)
+	_, err = newTestTransport().RoundTrip(req)
+	assert.Error(err)
+	assert.Equal(int32(0), atomic.LoadInt32(calls))
+}
+
+func TestRateLimitExceededError(t *testing.T) {
+	assert := assert.New(t)
+
+	err := &RateLimitExceededError{Reset: time.Now().Add(10 * time.Minute)}
+	assert.Contains(err.Error(), "Github API rate limit exceeded")
+	assert.Contains(err.Error(), "ackdev github quota")
+
+	err = &RateLimitExceededError{Secondary: true, Reset: time.Now()}
+	assert.Contains(err.Error(), "secondary API rate limit")
+}
+
+// dialErrorTransport fails the first failures requests with a dial error
+type dialErrorTransport struct {
+	failures int
+	calls    int
+}
+
+func (t *dialErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	if t.calls <= t.failures {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	}
+	return &http.Response{StatusCode: http.StatusCreated, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestRetryTransport_RoundTrip_DialError(t *testing.T) {
+	require := require.New(t)
+
+	// Requests that were never sent are retried, whatever their method
+	next := &dialErrorTransport{failures: 1}
+	transport := newTestTransport()
+	transport.next = next
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.github.com/repos/o/r/pulls", strings.NewReader("body"))
+	require.NoError(err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.Equal(http.StatusCreated, resp.StatusCode)
+	require.Equal(2, next.calls)
+}
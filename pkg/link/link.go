@@ -0,0 +1,189 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package link
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/controller"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/workspace"
+)
+
+const (
+	// CodeGeneratorModule is the module path of the ACK code-generator
+	CodeGeneratorModule = "github.com/aws-controllers-k8s/code-generator"
+)
+
+var (
+	ErrUnsupportedTarget = errors.New("unsupported link target")
+
+	// targetModules maps the repositories controllers can be linked to, to
+	// their module path.
+	targetModules = map[string]string{
+		"runtime":        controller.RuntimeModule,
+		"code-generator": CodeGeneratorModule,
+	}
+)
+
+// TargetModule returns the module path of a link target repository. It returns
+// ErrUnsupportedTarget if controllers can't be linked to the repository.
+func TargetModule(target string) (string, error) {
+	module, ok := targetModules[target]
+	if !ok {
+		targets := make([]string, 0, len(targetModules))
+		for t := range targetModules {
+			targets = append(targets, t)
+		}
+		sort.Strings(targets)
+		return "", fmt.Errorf("%w %s, expected one of: %s", ErrUnsupportedTarget, target, strings.Join(targets, ", "))
+	}
+	return module, nil
+}
+
+// Options contains the options used to link a controller to the local clone of
+// one of its dependencies.
+type Options struct {
+	// Repository is the controller repository name
+	Repository string
+	// RepositoryPath is the path of the controller local clone
+	RepositoryPath string
+	// Target is the dependency repository name, e.g runtime
+	Target string
+	// TargetPath is the path of the dependency local clone
+	TargetPath string
+	// Mode is the way the controller is linked
+	Mode Mode
+	// RootDirectory is the directory containing the go.work file, used in
+	// workspace mode
+	RootDirectory string
+}
+
+// Create links a controller to the local clone of one of its dependencies and
+// returns the link to record in the state.
+func Create(opts *Options) (*Link, error) {
+	module, err := TargetModule(opts.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	var replaced []string
+	switch opts.Mode {
+	case ModeReplace:
+		goModPath := filepath.Join(opts.RepositoryPath, "go.mod")
+		content, err := ioutil.ReadFile(goModPath)
+		if err != nil {
+			return nil, err
+		}
+		replaced, err = ReplacedDirectives(content, module, opts.TargetPath)
+		if err != nil {
+			return nil, err
+		}
+		content, err = controller.SetReplace(content, module, opts.TargetPath)
+		if err != nil {
+			return nil, err
+		}
+		err = ioutil.WriteFile(goModPath, content, 0644)
+		if err != nil {
+			return nil, err
+		}
+	case ModeWorkspace:
+		err = workspace.Use(opts.RootDirectory, opts.TargetPath, opts.RepositoryPath)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported link mode: %s", opts.Mode)
+	}
+
+	return &Link{
+		Repository: opts.Repository,
+		Target:     opts.Target,
+		Module:     module,
+		Path:       opts.TargetPath,
+		Mode:       opts.Mode,
+		Replaced:   replaced,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// ReplacedDirectives returns the replace directives of a module overwritten
+// when it's replaced by path, i.e all of them but the link directive itself.
+func ReplacedDirectives(content []byte, module, path string) ([]string, error) {
+	replaces, err := controller.Replaces(content, module)
+	if err != nil {
+		return nil, err
+	}
+	var replaced []string
+	for _, replace := range replaces {
+		if replace != linkDirective(module, path) {
+			replaced = append(replaced, replace)
+		}
+	}
+	return replaced, nil
+}
+
+// linkDirective returns the replace directive of a link, as returned by
+// controller.Replaces.
+func linkDirective(module, path string) string {
+	return module + " => " + path
+}
+
+// Delete reverts a link. In replace mode, the replace directive is removed from
+// the controller go.mod and the directives it overwrote are restored; it's a
+// no-op if the link directive was already removed. In workspace mode, the
+// controller is removed from the go.work file, along with the target when
+// keepTarget is false.
+func Delete(link *Link, repositoryPath, rootDirectory string, keepTarget bool) error {
+	switch link.Mode {
+	case ModeReplace:
+		goModPath := filepath.Join(repositoryPath, "go.mod")
+		content, err := ioutil.ReadFile(goModPath)
+		if err != nil {
+			return err
+		}
+		replaces, err := controller.Replaces(content, link.Module)
+		if err != nil {
+			return err
+		}
+		linked := false
+		for _, replace := range replaces {
+			linked = linked || replace == linkDirective(link.Module, link.Path)
+		}
+		if !linked {
+			return nil
+		}
+		content, _, err = controller.RemoveReplace(content, link.Module)
+		if err != nil {
+			return err
+		}
+		content, err = controller.AddReplaces(content, link.Replaced...)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(goModPath, content, 0644)
+	case ModeWorkspace:
+		dirs := []string{repositoryPath}
+		if !keepTarget {
+			dirs = append(dirs, link.Path)
+		}
+		return workspace.DropUse(rootDirectory, dirs...)
+	}
+	return fmt.Errorf("unsupported link mode: %s", link.Mode)
+}
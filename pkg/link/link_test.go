@@ -0,0 +1,170 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package link
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/controller"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/workspace"
+)
+
+const testGoMod = `module github.com/aws-controllers-k8s/s3-controller
+
+go 1.21
+
+require github.com/aws-controllers-k8s/runtime v0.30.0
+`
+
+func writeModule(t *testing.T, root, name, goMod string) string {
+	dir := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644))
+	return dir
+}
+
+func TestTargetModule(t *testing.T) {
+	assert := assert.New(t)
+
+	module, err := TargetModule("runtime")
+	assert.NoError(err)
+	assert.Equal(controller.RuntimeModule, module)
+
+	_, err = TargetModule("s3-controller")
+	assert.True(errors.Is(err, ErrUnsupportedTarget))
+	assert.Contains(err.Error(), "code-generator, runtime")
+}
+
+func TestCreateAndDelete_Replace(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	root := t.TempDir()
+	controllerPath := writeModule(t, root, "s3-controller", testGoMod)
+	runtimePath := filepath.Join(root, "runtime")
+
+	link, err := Create(&Options{
+		Repository:     "s3-controller",
+		RepositoryPath: controllerPath,
+		Target:         "runtime",
+		TargetPath:     runtimePath,
+		Mode:           ModeReplace,
+	})
+	require.NoError(err)
+	assert.Equal(controller.RuntimeModule, link.Module)
+	assert.Equal(runtimePath, link.Path)
+
+	content, err := ioutil.ReadFile(filepath.Join(controllerPath, "go.mod"))
+	require.NoError(err)
+	assert.Contains(string(content), "replace github.com/aws-controllers-k8s/runtime => "+runtimePath)
+
+	require.NoError(Delete(link, controllerPath, root, false))
+	content, err = ioutil.ReadFile(filepath.Join(controllerPath, "go.mod"))
+	require.NoError(err)
+	assert.Equal(testGoMod, string(content))
+
+	// deleting twice is a no-op
+	require.NoError(Delete(link, controllerPath, root, false))
+}
+
+func TestCreateAndDelete_Workspace(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	root := t.TempDir()
+	controllerPath := writeModule(t, root, "s3-controller", testGoMod)
+	runtimePath := writeModule(t, root, "runtime", "module github.com/aws-controllers-k8s/runtime\n\ngo 1.21\n")
+
+	link, err := Create(&Options{
+		Repository:     "s3-controller",
+		RepositoryPath: controllerPath,
+		Target:         "runtime",
+		TargetPath:     runtimePath,
+		Mode:           ModeWorkspace,
+		RootDirectory:  root,
+	})
+	require.NoError(err)
+
+	content, err := ioutil.ReadFile(workspace.GoWorkPath(root))
+	require.NoError(err)
+	assert.Contains(string(content), "./runtime")
+	assert.Contains(string(content), "./s3-controller")
+	// go.mod isn't modified in workspace mode
+	goMod, err := ioutil.ReadFile(filepath.Join(controllerPath, "go.mod"))
+	require.NoError(err)
+	assert.Equal(testGoMod, string(goMod))
+
+	require.NoError(Delete(link, controllerPath, root, true))
+	content, err = ioutil.ReadFile(workspace.GoWorkPath(root))
+	require.NoError(err)
+	assert.Contains(string(content), "./runtime")
+	assert.NotContains(string(content), "./s3-controller")
+
+	require.NoError(Delete(link, controllerPath, root, false))
+	content, err = ioutil.ReadFile(workspace.GoWorkPath(root))
+	require.NoError(err)
+	assert.NotContains(string(content), "./runtime")
+
+	_, err = Create(&Options{Target: "runtime", Mode: "symlink"})
+	assert.Error(err)
+}
+
+func TestCreateAndDelete_ReplaceExistingDirective(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	goMod := testGoMod + `
+replace github.com/aws-controllers-k8s/runtime => github.com/fork/runtime v0.30.1
+`
+	root := t.TempDir()
+	controllerPath := writeModule(t, root, "s3-controller", goMod)
+	runtimePath := filepath.Join(root, "runtime")
+	opts := &Options{
+		Repository:     "s3-controller",
+		RepositoryPath: controllerPath,
+		Target:         "runtime",
+		TargetPath:     runtimePath,
+		Mode:           ModeReplace,
+	}
+
+	link, err := Create(opts)
+	require.NoError(err)
+	assert.Equal([]string{"github.com/aws-controllers-k8s/runtime => github.com/fork/runtime v0.30.1"}, link.Replaced)
+	content, err := ioutil.ReadFile(filepath.Join(controllerPath, "go.mod"))
+	require.NoError(err)
+	assert.NotContains(string(content), "github.com/fork/runtime")
+
+	// linking twice doesn't record the link directive as overwritten
+	relink, err := Create(opts)
+	require.NoError(err)
+	assert.Empty(relink.Replaced)
+
+	require.NoError(Delete(link, controllerPath, root, false))
+	content, err = ioutil.ReadFile(filepath.Join(controllerPath, "go.mod"))
+	require.NoError(err)
+	assert.Equal(goMod, string(content))
+
+	// deleting twice is a no-op
+	require.NoError(Delete(link, controllerPath, root, false))
+	content, err = ioutil.ReadFile(filepath.Join(controllerPath, "go.mod"))
+	require.NoError(err)
+	assert.Equal(goMod, string(content))
+}
@@ -0,0 +1,141 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package link
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ghodss/yaml"
+)
+
+// Mode is the way a controller is linked to a local module.
+type Mode string
+
+const (
+	// ModeReplace links a controller using a replace directive in its go.mod
+	ModeReplace Mode = "replace"
+	// ModeWorkspace links a controller using the go.work file of the root
+	// directory
+	ModeWorkspace Mode = "workspace"
+)
+
+// Link records a controller linked to the local clone of one of its
+// dependencies.
+type Link struct {
+	// Repository is the linked controller repository name
+	Repository string `yaml:"repository" json:"repository"`
+	// Target is the linked dependency repository name, e.g runtime
+	Target string `yaml:"target" json:"target"`
+	// Module is the linked dependency module path
+	Module string `yaml:"module" json:"module"`
+	// Path is the path of the dependency local clone
+	Path string `yaml:"path" json:"path"`
+	// Mode is the way the controller is linked
+	Mode Mode `yaml:"mode" json:"mode"`
+	// Replaced are the replace directives of the module overwritten by the
+	// link in replace mode, restored when the link is deleted
+	Replaced []string `yaml:"replaced,omitempty" json:"replaced,omitempty"`
+	// CreatedAt is the link creation time
+	CreatedAt time.Time `yaml:"createdAt" json:"createdAt"`
+}
+
+// State records the controllers linked by ackdev, allowing them to be unlinked
+// and warning about them before they are committed.
+type State struct {
+	Links []*Link `yaml:"links" json:"links"`
+}
+
+// LoadState reads the links state file. An empty state is returned if the
+// file doesn't exist.
+func LoadState(statePath string) (*State, error) {
+	content, err := ioutil.ReadFile(statePath)
+	if os.IsNotExist(err) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{}
+	err = yaml.Unmarshal(content, state)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// SaveState writes the links state file, creating its parent directory if
+// needed.
+func SaveState(state *State, statePath string) error {
+	bytes, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(statePath), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(statePath, bytes, 0644)
+}
+
+// Add records a link, replacing the existing link of the repository to the
+// same target. When both links are in replace mode, the replace directives
+// overwritten by the existing link are kept, since the go.mod now only contains
+// the existing link directive.
+func (s *State) Add(link *Link) {
+	for _, existing := range s.RepositoryLinks(link.Repository) {
+		if existing.Target == link.Target && existing.Mode == ModeReplace && link.Mode == ModeReplace {
+			link.Replaced = existing.Replaced
+		}
+	}
+	s.Remove(link.Repository, link.Target)
+	s.Links = append(s.Links, link)
+}
+
+// Remove removes the link of a repository to a target. It returns false if the
+// repository isn't linked to the target.
+func (s *State) Remove(repository, target string) bool {
+	for i, link := range s.Links {
+		if link.Repository == repository && link.Target == target {
+			s.Links = append(s.Links[:i], s.Links[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// RepositoryLinks returns the links of a repository.
+func (s *State) RepositoryLinks(repository string) []*Link {
+	links := []*Link{}
+	for _, link := range s.Links {
+		if link.Repository == repository {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// TargetLinks returns the links to a target using the given mode.
+func (s *State) TargetLinks(target string, mode Mode) []*Link {
+	links := []*Link{}
+	for _, link := range s.Links {
+		if link.Target == target && link.Mode == mode {
+			links = append(links, link)
+		}
+	}
+	return links
+}
@@ -0,0 +1,62 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package link
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	statePath := filepath.Join(t.TempDir(), ".ackdev", "links.yaml")
+	state, err := LoadState(statePath)
+	require.NoError(err)
+	assert.Empty(state.Links)
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	state.Add(&Link{Repository: "s3-controller", Target: "runtime", Mode: ModeReplace, CreatedAt: createdAt})
+	state.Add(&Link{Repository: "ecr-controller", Target: "runtime", Mode: ModeWorkspace, CreatedAt: createdAt})
+	state.Add(&Link{Repository: "s3-controller", Target: "code-generator", Mode: ModeReplace, CreatedAt: createdAt})
+	// replaces the existing link
+	state.Add(&Link{Repository: "s3-controller", Target: "runtime", Mode: ModeWorkspace, CreatedAt: createdAt})
+	require.Len(state.Links, 3)
+
+	require.NoError(SaveState(state, statePath))
+	loaded, err := LoadState(statePath)
+	require.NoError(err)
+	assert.Equal(state, loaded)
+
+	assert.Len(loaded.RepositoryLinks("s3-controller"), 2)
+	assert.Empty(loaded.RepositoryLinks("sns-controller"))
+	assert.Len(loaded.TargetLinks("runtime", ModeWorkspace), 2)
+	assert.Empty(loaded.TargetLinks("runtime", ModeReplace))
+
+	assert.True(loaded.Remove("s3-controller", "runtime"))
+	assert.False(loaded.Remove("s3-controller", "runtime"))
+	assert.Len(loaded.Links, 2)
+
+	// relinking keeps the replace directives overwritten by the first link
+	replaced := []string{"github.com/aws-controllers-k8s/runtime => ../runtime"}
+	loaded.Add(&Link{Repository: "s3-controller", Target: "runtime", Mode: ModeReplace, Replaced: replaced})
+	loaded.Add(&Link{Repository: "s3-controller", Target: "runtime", Mode: ModeReplace, Path: "/src/runtime"})
+	require.Len(loaded.Links, 3)
+	assert.Equal(replaced, loaded.Links[2].Replaced)
+}
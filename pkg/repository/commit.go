@@ -0,0 +1,139 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrBranchAlreadyExists = errors.New("branch already exists")
+	ErrNothingToCommit     = errors.New("nothing to commit")
+)
+
+// CreateBranch creates a new branch pointing to HEAD and checks it out. The
+// uncommitted changes are kept. It returns ErrBranchAlreadyExists if the
+// branch exists.
+func (r *Repository) CreateBranch(name string) error {
+	if r.gitRepo == nil {
+		return ErrRepositoryDoesntExist
+	}
+
+	branch := plumbing.NewBranchReferenceName(name)
+	_, err := r.gitRepo.Reference(branch, false)
+	if err == nil {
+		return fmt.Errorf("%w: %s", ErrBranchAlreadyExists, name)
+	}
+	if err != plumbing.ErrReferenceNotFound {
+		return err
+	}
+
+	head, err := r.gitRepo.Head()
+	if err != nil {
+		return err
+	}
+	worktree, err := r.gitRepo.Worktree()
+	if err != nil {
+		return err
+	}
+	err = worktree.Checkout(&git.CheckoutOptions{
+		Branch: branch,
+		Create: true,
+		Keep:   true,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot checkout branch %s: %v", name, err)
+	}
+	r.branchedFrom = head
+	r.GitHead = name
+	return nil
+}
+
+// AbortBranch rolls back the creation of a branch by CreateBranch: it discards
+// the worktree changes, including the untracked files, checks out the branch
+// (or commit) previously checked out and deletes the created branch. It must
+// only be called if the worktree was clean when the branch was created.
+func (r *Repository) AbortBranch(name string) error {
+	if r.gitRepo == nil {
+		return ErrRepositoryDoesntExist
+	}
+	if r.branchedFrom == nil {
+		return fmt.Errorf("branch %s wasn't created by ackdev", name)
+	}
+
+	worktree, err := r.gitRepo.Worktree()
+	if err != nil {
+		return err
+	}
+	err = worktree.Reset(&git.ResetOptions{Mode: git.HardReset})
+	if err != nil {
+		return fmt.Errorf("cannot discard changes: %v", err)
+	}
+	err = worktree.Clean(&git.CleanOptions{Dir: true})
+	if err != nil {
+		return fmt.Errorf("cannot remove untracked files: %v", err)
+	}
+
+	checkout := &git.CheckoutOptions{Branch: r.branchedFrom.Name()}
+	if !r.branchedFrom.Name().IsBranch() {
+		// HEAD was detached
+		checkout = &git.CheckoutOptions{Hash: r.branchedFrom.Hash()}
+	}
+	if err := worktree.Checkout(checkout); err != nil {
+		return fmt.Errorf("cannot checkout %s: %v", r.branchedFrom.Name().Short(), err)
+	}
+	r.GitHead = r.branchedFrom.Name().Short()
+	r.branchedFrom = nil
+
+	err = r.gitRepo.Storer.RemoveReference(plumbing.NewBranchReferenceName(name))
+	if err != nil {
+		return fmt.Errorf("cannot delete branch %s: %v", name, err)
+	}
+	return nil
+}
+
+// CommitAll stages all the changes of the worktree, including the untracked
+// files, and commits them. The commit author is read from the git
+// configuration. It returns ErrNothingToCommit if the worktree is clean.
+func (r *Repository) CommitAll(message string) (string, error) {
+	if r.gitRepo == nil {
+		return "", ErrRepositoryDoesntExist
+	}
+
+	worktree, err := r.gitRepo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	err = worktree.AddWithOptions(&git.AddOptions{All: true})
+	if err != nil {
+		return "", fmt.Errorf("cannot stage changes: %v", err)
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return "", err
+	}
+	if status.IsClean() {
+		return "", ErrNothingToCommit
+	}
+
+	hash, err := worktree.Commit(message, &git.CommitOptions{})
+	if err != nil {
+		return "", fmt.Errorf("cannot commit: %v", err)
+	}
+	return hash.String(), nil
+}
@@ -0,0 +1,80 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/testutil"
+)
+
+func TestRepository_CreateBranchAndCommitAll(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	err := (&Repository{Name: "s3-controller"}).CreateBranch("bump")
+	assert.Equal(ErrRepositoryDoesntExist, err)
+
+	gitRepo, err := testutil.NewInMemoryGitRepository()
+	require.NoError(err)
+	cfg, err := gitRepo.Config()
+	require.NoError(err)
+	cfg.User.Name = "ack-bot"
+	cfg.User.Email = "ack-bot@ack"
+	require.NoError(gitRepo.SetConfig(cfg))
+	repo := &Repository{Name: "s3-controller", gitRepo: gitRepo, GitHead: "master"}
+
+	_, err = repo.CommitAll("nothing")
+	assert.Equal(ErrNothingToCommit, err)
+
+	// uncommitted changes are kept in the new branch
+	worktree, err := gitRepo.Worktree()
+	require.NoError(err)
+	require.NoError(util.WriteFile(worktree.Filesystem, "ramanujan_serie.txt", []byte("1 + 1 = 2"), 0644))
+	require.NoError(util.WriteFile(worktree.Filesystem, "go.mod", []byte("module s3"), 0644))
+
+	require.NoError(repo.CreateBranch("bump"))
+	assert.Equal("bump", repo.GitHead)
+	err = repo.CreateBranch("bump")
+	assert.True(errors.Is(err, ErrBranchAlreadyExists))
+
+	hash, err := repo.CommitAll("Bump runtime")
+	require.NoError(err)
+
+	head, err := gitRepo.Head()
+	require.NoError(err)
+	assert.Equal(plumbing.NewBranchReferenceName("bump"), head.Name())
+	assert.Equal(hash, head.Hash().String())
+	commit, err := gitRepo.CommitObject(head.Hash())
+	require.NoError(err)
+	assert.Equal("Bump runtime", commit.Message)
+	assert.Equal("ack-bot", commit.Author.Name)
+	stats, err := commit.Stats()
+	require.NoError(err)
+	assert.Len(stats, 2)
+
+	status, err := worktree.Status()
+	require.NoError(err)
+	assert.True(status.IsClean())
+
+	master, err := gitRepo.Reference(plumbing.NewBranchReferenceName("master"), false)
+	require.NoError(err)
+	assert.NotEqual(master.Hash(), head.Hash())
+}
@@ -0,0 +1,52 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+// EnsureStep is one of the steps executed to ensure a repository.
+type EnsureStep string
+
+const (
+	EnsureStepFork    EnsureStep = "fork"
+	EnsureStepClone   EnsureStep = "clone"
+	EnsureStepRemotes EnsureStep = "remotes"
+)
+
+// EnsureResult is the outcome of ensuring a single repository.
+type EnsureResult struct {
+	// Repository is the ensured repository
+	Repository *Repository
+	// Step is the step that failed. Empty if all the steps succeeded.
+	Step EnsureStep
+	// Err is the error returned by the failing step.
+	Err error
+}
+
+// Failed returns true if one of the ensure steps failed.
+func (r *EnsureResult) Failed() bool {
+	return r.Err != nil
+}
+
+// EnsureResults is the list of results returned by Manager.EnsureAll
+type EnsureResults []*EnsureResult
+
+// Failed returns the results of the repositories that couldn't be ensured.
+func (rs EnsureResults) Failed() EnsureResults {
+	failed := EnsureResults{}
+	for _, r := range rs {
+		if r.Failed() {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
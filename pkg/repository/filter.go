@@ -44,6 +44,9 @@ func BuildFilters(expression string) ([]Filter, error) {
 		value := filterArgs[1]
 		switch key {
 		case "type":
+			if _, err := ParseRepositoryType(value); err != nil {
+				return nil, err
+			}
 			filters = append(filters, TypeFilter(value))
 		case "name":
 			filters = append(filters, NameFilter(value))
@@ -81,8 +84,9 @@ func NamePrefixFilter(namePrefix string) Filter {
 // TypeFilter filters a repository by a name prefix
 // The only two possible types are 'controller' and 'core'
 func TypeFilter(t string) Filter {
+	repoType, err := ParseRepositoryType(t)
 	return func(r *Repository) bool {
-		return r.Type == GetRepositoryTypeFromString(t)
+		return err == nil && r.Type == repoType
 	}
 }
 
@@ -14,6 +14,7 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -56,6 +57,12 @@ func TestBuildFilters(t *testing.T) {
 			wantLenFilter: 0,
 			wantErr:       true,
 		},
+		{
+			name:          "unknown repository type",
+			args:          args{expression: "type=tooling"},
+			wantLenFilter: 0,
+			wantErr:       true,
+		},
 		{
 			name:          "correct expression",
 			args:          args{expression: "type=core"},
@@ -123,6 +130,18 @@ func TestTypeFilter(t *testing.T) {
 	}
 	assert.True(t, repoTypeFilter(runtimeRepo))
 	assert.False(t, repoTypeFilter(sqsRepo))
+	assert.False(t, TypeFilter("tooling")(runtimeRepo))
+}
+
+func TestParseRepositoryType(t *testing.T) {
+	assert := assert.New(t)
+
+	repoType, err := ParseRepositoryType("controller")
+	assert.NoError(err)
+	assert.Equal(RepositoryTypeController, repoType)
+
+	_, err = ParseRepositoryType("tooling")
+	assert.True(errors.Is(err, ErrUnknownRepositoryType))
 }
 
 func TestBranchFilter(t *testing.T) {
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	git "github.com/go-git/go-git/v5"
@@ -27,6 +28,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/credentials"
 	ackdevgit "github.com/aws-controllers-k8s/dev-tools/pkg/git"
 	"github.com/aws-controllers-k8s/dev-tools/pkg/github"
 	"github.com/aws-controllers-k8s/dev-tools/pkg/util"
@@ -35,6 +37,9 @@ import (
 const (
 	originRemoteName   = "origin"
 	upstreamRemoteName = "upstream"
+
+	defaultForkPollInterval = 1 * time.Second
+	maxForkPollInterval     = 15 * time.Second
 )
 
 var (
@@ -47,33 +52,57 @@ var (
 
 // NewManager create a new manager.
 func NewManager(cfg *config.Config) (*Manager, error) {
-	githubClient := github.NewClient(cfg.Github.Token)
+	tokenProvider, err := credentials.NewProviderFromConfig(&cfg.Github)
+	if err != nil {
+		return nil, err
+	}
+
+	githubClient, err := github.NewClientFromConfig(tokenProvider, &cfg.Github)
+	if err != nil {
+		return nil, err
+	}
+
 	gitOpts := []ackdevgit.Option{
 		ackdevgit.WithRemote(originRemoteName),
 	}
-	urlBuilder := httpsRemoteURL
+	urlBuilder := httpsRemoteURLBuilder(cfg.Github.GetHost())
 
 	// Add git authentication options
-	if cfg.Git.SSHKeyPath == "" {
+	if !cfg.Git.UseSSH() {
 		gitOpts = append(gitOpts,
-			ackdevgit.WithGithubCredentials(cfg.Github.Username, cfg.Github.Token),
+			ackdevgit.WithGithubCredentials(cfg.Github.Username, tokenProvider),
 		)
 	} else {
-		// TODO(hilalymh) set ssh.Signer here.. figure out how to deal with encrypted
-		// keys properly...
-		gitOpts = append(gitOpts, ackdevgit.WithSSHSigner(nil))
-		urlBuilder = sshRemoteURL
+		// Prefer the configured ssh key and fallback to the ssh-agent. Keys are
+		// loaded lazily to only prompt for passphrases when needed.
+		if cfg.Git.SSHKeyPath != "" {
+			gitOpts = append(gitOpts, ackdevgit.WithSSHKeyPath(cfg.Git.SSHKeyPath))
+		} else {
+			gitOpts = append(gitOpts, ackdevgit.WithSSHAgent())
+		}
+		if cfg.Git.KnownHostsPath != "" {
+			gitOpts = append(gitOpts, ackdevgit.WithKnownHostsFiles(cfg.Git.KnownHostsPath))
+		}
+		urlBuilder = sshRemoteURLBuilder(cfg.Github.GetHost())
 	}
 
 	gitClient := ackdevgit.New(gitOpts...)
 
+	forkTimeout, err := cfg.Github.GetForkTimeout()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Manager{
 		repoCache: make(map[string]*Repository),
 
 		cfg:        cfg,
 		ghc:        githubClient,
 		git:        gitClient,
+		gitRemote:  gitClient,
 		urlBuilder: urlBuilder,
+
+		forkTimeout: forkTimeout,
 	}, nil
 }
 
@@ -85,8 +114,16 @@ type Manager struct {
 	log        *logrus.Logger
 	cfg        *config.Config
 	git        ackdevgit.OpenCloner
+	gitRemote  ackdevgit.FetchPusher
 	ghc        github.RepositoryService
 	urlBuilder func(owner, repo string) string
+
+	// forkTimeout is the maximum duration to wait for a new fork to be
+	// accessible. Defaults to config.DefaultForkTimeout.
+	forkTimeout time.Duration
+	// forkPollInterval is the initial interval between fork readiness
+	// checks. Defaults to defaultForkPollInterval.
+	forkPollInterval time.Duration
 }
 
 // LoadRepository loads information about a single local repository
@@ -172,6 +209,21 @@ func (m *Manager) getRepository(repoName string) (*Repository, error) {
 	return repo, nil
 }
 
+// GetRepository returns a cached repository. The name can either be the name
+// used in the configuration (e.g s3) or the repository name (e.g s3-controller).
+func (m *Manager) GetRepository(name string) (*Repository, error) {
+	repo, err := m.getRepository(name)
+	if err == nil {
+		return repo, nil
+	}
+	for _, repo := range m.repoCache {
+		if repo.Name == name {
+			return repo, nil
+		}
+	}
+	return nil, ErrRepositoryNotCached
+}
+
 // List returns the list of all the cached repositories
 func (m *Manager) List(filters ...Filter) []*Repository {
 	repos := []*Repository{}
@@ -208,7 +260,7 @@ func (m *Manager) clone(ctx context.Context, repoName string) error {
 	// clone fork repository with original name
 	err = m.git.Clone(
 		ctx,
-		m.urlBuilder(m.cfg.Github.Username, repo.ExpectedForkName),
+		m.urlBuilder(m.cfg.Github.GetForkOwner(), repo.ExpectedForkName),
 		repo.FullPath,
 	)
 	if errors.Is(err, transport.ErrAuthenticationRequired) {
@@ -225,13 +277,16 @@ func (m *Manager) clone(ctx context.Context, repoName string) error {
 		return err
 	}
 
-	// set repository git object
+	// set repository git object and current branch
 	repo.gitRepo = gitRepo
+	if head, err := gitRepo.Head(); err == nil {
+		repo.GitHead = head.Name().Short()
+	}
 
 	// Add upstream remote
 	_, err = gitRepo.CreateRemote(&gitconfig.RemoteConfig{
 		Name: upstreamRemoteName,
-		URLs: []string{m.urlBuilder(github.ACKOrg, repo.Name)},
+		URLs: []string{m.urlBuilder(m.cfg.Github.GetOrganization(), repo.Name)},
 	})
 
 	if err != nil {
@@ -247,31 +302,73 @@ func (m *Manager) clone(ctx context.Context, repoName string) error {
 func (m *Manager) EnsureFork(ctx context.Context, repo *Repository) error {
 	// TODO(hilaly): m.log.SetLevel(logrus.DebugLevel)
 
-	fork, err := m.ghc.GetUserRepositoryFork(ctx, m.cfg.Github.Username, repo.Name)
+	owner := m.cfg.Github.GetForkOwner()
+	fork, err := m.ghc.GetUserRepositoryFork(ctx, owner, repo.Name, repo.ExpectedForkName)
 	if err == nil {
 		if *fork.Name != repo.ExpectedForkName {
-			err = m.ghc.RenameRepository(ctx, m.cfg.Github.Username, *fork.Name, repo.ExpectedForkName)
+			err = m.ghc.RenameRepository(ctx, owner, *fork.Name, repo.ExpectedForkName)
 			if err != nil {
 				return err
 			}
 		}
 	} else if err == github.ErrForkNotFound {
-		err = m.ghc.ForkRepository(ctx, repo.Name)
+		fork, err = m.ghc.ForkRepository(ctx, repo.Name, repo.ExpectedForkName)
 		if err != nil {
 			return err
 		}
 
-		time.Sleep(1 * time.Second)
+		// Github instances that don't support naming forks create them with
+		// the upstream repository name.
+		forkName := repo.ExpectedForkName
+		if fork != nil && fork.GetName() != "" {
+			forkName = fork.GetName()
+		}
 
-		err = m.ghc.RenameRepository(ctx, m.cfg.Github.Username, repo.Name, repo.ExpectedForkName)
+		err = m.waitForFork(ctx, forkName)
 		if err != nil {
 			return err
 		}
+
+		if forkName != repo.ExpectedForkName {
+			return m.ghc.RenameRepository(ctx, owner, forkName, repo.ExpectedForkName)
+		}
 		return nil
 	}
 	return err
 }
 
+// waitForFork polls Github, with an exponential backoff, until the user fork is
+// accessible or the fork timeout is reached.
+func (m *Manager) waitForFork(ctx context.Context, forkName string) error {
+	timeout := m.forkTimeout
+	if timeout == 0 {
+		timeout = config.DefaultForkTimeout
+	}
+	interval := m.forkPollInterval
+	if interval == 0 {
+		interval = defaultForkPollInterval
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	backoff := &util.Backoff{Initial: interval, Max: maxForkPollInterval}
+	for {
+		_, err := m.ghc.GetRepository(ctx, m.cfg.Github.GetForkOwner(), forkName)
+		if err == nil {
+			return nil
+		}
+		if !github.IsNotFound(err) {
+			return fmt.Errorf("cannot get fork %s: %v", forkName, err)
+		}
+
+		err = util.Sleep(ctx, backoff.Next())
+		if err != nil {
+			return fmt.Errorf("fork %s is not accessible after %s: %v", forkName, timeout, err)
+		}
+	}
+}
+
 func (m *Manager) EnsureClone(ctx context.Context, repo *Repository) error {
 	err := m.clone(ctx, repo.Name)
 	if err != nil && err != ErrRepositoryAlreadyExist {
@@ -289,22 +386,29 @@ func (m *Manager) EnsureRepository(ctx context.Context, name string) error {
 		return err
 	}
 
-	err = m.EnsureFork(ctx, repo)
+	_, err = m.ensure(ctx, repo)
+	return err
+}
+
+// ensure runs the fork, clone and remotes steps for a given repository. It
+// stops at the first failing step and returns it along with the error.
+func (m *Manager) ensure(ctx context.Context, repo *Repository) (EnsureStep, error) {
+	err := m.EnsureFork(ctx, repo)
 	if err != nil {
-		return err
+		return EnsureStepFork, err
 	}
 
 	err = m.EnsureClone(ctx, repo)
 	if err != nil {
-		return err
+		return EnsureStepClone, err
 	}
 
 	err = m.EnsureRemotes(ctx, repo)
 	if err != nil {
-		return err
+		return EnsureStepRemotes, err
 	}
 
-	return nil
+	return "", nil
 }
 
 // EnsureRemotes ensures that the local repositories have both origin and upstream
@@ -315,7 +419,7 @@ func (m *Manager) EnsureRemotes(ctx context.Context, repo *Repository) error {
 		return err
 	}
 
-	expecetedOriginURL := m.urlBuilder(m.cfg.Github.Username, repo.ExpectedForkName)
+	expecetedOriginURL := m.urlBuilder(m.cfg.Github.GetForkOwner(), repo.ExpectedForkName)
 	// First check that one fo the  origin URLs points to the fork url
 	originURLs, ok := remotes[originRemoteName]
 	if !ok || !util.InStrings(expecetedOriginURL, originURLs) {
@@ -326,7 +430,7 @@ func (m *Manager) EnsureRemotes(ctx context.Context, repo *Repository) error {
 		}
 	}
 
-	expectedUpstreamURL := m.urlBuilder(github.ACKOrg, repo.Name)
+	expectedUpstreamURL := m.urlBuilder(m.cfg.Github.GetOrganization(), repo.Name)
 	// Then check that one of the upstream URLs points to the original
 	// repository
 	upstreamURLs, ok := remotes[upstreamRemoteName]
@@ -340,23 +444,44 @@ func (m *Manager) EnsureRemotes(ctx context.Context, repo *Repository) error {
 	return nil
 }
 
-// EnsureAll ensures all cached repositories.
-func (m *Manager) EnsureAll(ctx context.Context) error {
-	for _, repo := range m.repoCache {
-		err := m.EnsureFork(ctx, repo)
-		if err != nil {
-			return err
+// EnsureAll ensures all cached repositories. Repositories are ensured in parallel
+// using at most maxWorkers goroutines. A failure on one repository doesn't stop
+// the others from being ensured; the returned results contain the status of each
+// repository, in the same order as List.
+func (m *Manager) EnsureAll(ctx context.Context, maxWorkers int) EnsureResults {
+	repos := m.List()
+	results := make(EnsureResults, len(repos))
+	ForEach(repos, maxWorkers, func(i int, repo *Repository) {
+		result := &EnsureResult{Repository: repo}
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+		} else {
+			result.Step, result.Err = m.ensure(ctx, repo)
 		}
+		results[i] = result
+	})
+	return results
+}
 
-		err = m.EnsureClone(ctx, repo)
-		if err != nil {
-			return err
-		}
+// ForEach calls fn for each repository using at most maxWorkers
+// goroutines. It blocks until all the calls return.
+func ForEach(repos []*Repository, maxWorkers int, fn func(i int, repo *Repository)) {
+	if maxWorkers < 1 {
+		maxWorkers = 1
+	}
 
-		err = m.EnsureRemotes(ctx, repo)
-		if err != nil {
-			return err
-		}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxWorkers)
+	for i, repo := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, repo *Repository) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i, repo)
+		}(i, repo)
 	}
-	return nil
+	wg.Wait()
 }
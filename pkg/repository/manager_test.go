@@ -18,13 +18,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	gitconfig "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	gogithub "github.com/google/go-github/v61/github"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/aws-controllers-k8s/dev-tools/pkg/config"
@@ -272,7 +275,7 @@ func TestManager_clone(t *testing.T) {
 			fields: fields{
 				cfg:        testutil.NewConfig("s3", "elasticache"),
 				git:        fakeGit,
-				urlBuilder: httpsRemoteURL,
+				urlBuilder: httpsRemoteURLBuilder("github.com"),
 				repoCache:  make(map[string]*Repository),
 			},
 			args: args{
@@ -285,7 +288,7 @@ func TestManager_clone(t *testing.T) {
 			fields: fields{
 				cfg:        testutil.NewConfig("mq"),
 				git:        fakeGit,
-				urlBuilder: httpsRemoteURL,
+				urlBuilder: httpsRemoteURLBuilder("github.com"),
 				repoCache:  make(map[string]*Repository),
 			},
 			args: args{
@@ -298,7 +301,7 @@ func TestManager_clone(t *testing.T) {
 			fields: fields{
 				cfg:        testutil.NewConfig("sagemaker"),
 				git:        fakeGit,
-				urlBuilder: httpsRemoteURL,
+				urlBuilder: httpsRemoteURLBuilder("github.com"),
 				repoCache:  make(map[string]*Repository),
 			},
 			args: args{
@@ -332,12 +335,14 @@ func TestManager_EnsureFork(t *testing.T) {
 		testingCtx,
 		"ack-bot",
 		"s3-controller",
+		"s3-sagemaker-controller",
 	).Return(nil, github.ErrForkNotFound)
 	fakeGithubClient.On(
 		"ForkRepository",
 		testingCtx,
 		"s3-controller",
-	).Return(errors.New("unknown error"))
+		"s3-sagemaker-controller",
+	).Return(nil, errors.New("unknown error"))
 
 	// sagemaker case
 	fakeGithubClient.On(
@@ -345,6 +350,7 @@ func TestManager_EnsureFork(t *testing.T) {
 		testingCtx,
 		"ack-bot",
 		"sagemaker-controller",
+		"ack-sagemaker-controller",
 	).Return(&gogithub.Repository{Name: stringPtr("sagemaker-controller")}, nil)
 	fakeGithubClient.On(
 		"RenameRepository",
@@ -360,12 +366,20 @@ func TestManager_EnsureFork(t *testing.T) {
 		testingCtx,
 		"ack-bot",
 		"ecr-controller",
+		"ack-ecr-controller",
 	).Return(nil, github.ErrForkNotFound)
 	fakeGithubClient.On(
 		"ForkRepository",
 		testingCtx,
 		"ecr-controller",
-	).Return(nil)
+		"ack-ecr-controller",
+	).Return(&gogithub.Repository{Name: stringPtr("ecr-controller")}, nil)
+	fakeGithubClient.On(
+		"GetRepository",
+		mock.Anything,
+		"ack-bot",
+		"ecr-controller",
+	).Return(&gogithub.Repository{Name: stringPtr("ecr-controller")}, nil)
 	fakeGithubClient.On(
 		"RenameRepository",
 		testingCtx,
@@ -374,6 +388,93 @@ func TestManager_EnsureFork(t *testing.T) {
 		"ack-ecr-controller",
 	).Return(nil)
 
+	// sns case: the fork becomes accessible after a few polls
+	fakeGithubClient.On(
+		"GetUserRepositoryFork",
+		testingCtx,
+		"ack-bot",
+		"sns-controller",
+		"ack-sns-controller",
+	).Return(nil, github.ErrForkNotFound)
+	fakeGithubClient.On(
+		"ForkRepository",
+		testingCtx,
+		"sns-controller",
+		"ack-sns-controller",
+	).Return(nil, nil)
+	fakeGithubClient.On(
+		"GetRepository",
+		mock.Anything,
+		"ack-bot",
+		"ack-sns-controller",
+	).Return(nil, notFoundError()).Twice()
+	fakeGithubClient.On(
+		"GetRepository",
+		mock.Anything,
+		"ack-bot",
+		"ack-sns-controller",
+	).Return(&gogithub.Repository{Name: stringPtr("ack-sns-controller")}, nil).Once()
+
+	// sqs case: the fork never becomes accessible
+	fakeGithubClient.On(
+		"GetUserRepositoryFork",
+		testingCtx,
+		"ack-bot",
+		"sqs-controller",
+		"ack-sqs-controller",
+	).Return(nil, github.ErrForkNotFound)
+	fakeGithubClient.On(
+		"ForkRepository",
+		testingCtx,
+		"sqs-controller",
+		"ack-sqs-controller",
+	).Return(nil, nil)
+	fakeGithubClient.On(
+		"GetRepository",
+		mock.Anything,
+		"ack-bot",
+		"ack-sqs-controller",
+	).Return(nil, notFoundError())
+
+	// mq case: polling fails with an unexpected error
+	fakeGithubClient.On(
+		"GetUserRepositoryFork",
+		testingCtx,
+		"ack-bot",
+		"mq-controller",
+		"ack-mq-controller",
+	).Return(nil, github.ErrForkNotFound)
+	fakeGithubClient.On(
+		"ForkRepository",
+		testingCtx,
+		"mq-controller",
+		"ack-mq-controller",
+	).Return(&gogithub.Repository{Name: stringPtr("ack-mq-controller")}, nil)
+	fakeGithubClient.On(
+		"GetRepository",
+		mock.Anything,
+		"ack-bot",
+		"ack-mq-controller",
+	).Return(nil, errors.New("unknown error"))
+
+	// eks case: the fork is owned by an organization
+	eksConfig := testutil.NewConfig("eks")
+	eksConfig.Github.ForkOrganization = "ack-org"
+	fakeGithubClient.On(
+		"GetUserRepositoryFork",
+		testingCtx,
+		"ack-org",
+		"eks-controller",
+		"ack-eks-controller",
+	).Return(&gogithub.Repository{Name: stringPtr("eks-controller")}, nil)
+	fakeGithubClient.On(
+		"RenameRepository",
+		testingCtx,
+		"ack-org",
+		"eks-controller",
+		"ack-eks-controller",
+	).Return(nil)
+
 	type fields struct {
 		cfg       *config.Config
 		ghc       github.RepositoryService
@@ -434,6 +535,66 @@ func TestManager_EnsureFork(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "ensure fork successful - wait for fork",
+			fields: fields{
+				cfg:       testutil.NewConfig("sns"),
+				ghc:       fakeGithubClient,
+				repoCache: make(map[string]*Repository),
+			},
+			args: args{
+				repo: &Repository{
+					Name:             "sns-controller",
+					ExpectedForkName: "ack-sns-controller",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure fork successful - organization fork",
+			fields: fields{
+				cfg:       eksConfig,
+				ghc:       fakeGithubClient,
+				repoCache: make(map[string]*Repository),
+			},
+			args: args{
+				repo: &Repository{
+					Name:             "eks-controller",
+					ExpectedForkName: "ack-eks-controller",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fork not accessible before timeout",
+			fields: fields{
+				cfg:       testutil.NewConfig("sqs"),
+				ghc:       fakeGithubClient,
+				repoCache: make(map[string]*Repository),
+			},
+			args: args{
+				repo: &Repository{
+					Name:             "sqs-controller",
+					ExpectedForkName: "ack-sqs-controller",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "wait for fork error",
+			fields: fields{
+				cfg:       testutil.NewConfig("mq"),
+				ghc:       fakeGithubClient,
+				repoCache: make(map[string]*Repository),
+			},
+			args: args{
+				repo: &Repository{
+					Name:             "mq-controller",
+					ExpectedForkName: "ack-mq-controller",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -442,6 +603,9 @@ func TestManager_EnsureFork(t *testing.T) {
 				ghc:       tt.fields.ghc,
 				git:       tt.fields.git,
 				repoCache: tt.fields.repoCache,
+
+				forkTimeout:      50 * time.Millisecond,
+				forkPollInterval: time.Millisecond,
 			}
 			if err := m.EnsureFork(testingCtx, tt.args.repo); (err != nil) != tt.wantErr {
 				t.Errorf("Manager.ensureFork() error = %v, wantErr %v", err, tt.wantErr)
@@ -449,3 +613,83 @@ func TestManager_EnsureFork(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_EnsureAll(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	s3Repo, err := testutil.NewInMemoryGitRepository()
+	require.NoError(err)
+	sqsRepo, err := testutil.NewInMemoryGitRepository()
+	require.NoError(err)
+
+	fakeGithubClient := &mocks.RepositoryService{}
+	fakeGithubClient.On(
+		"GetUserRepositoryFork",
+		testingCtx,
+		"ack-bot",
+		"s3-controller",
+		"ack-s3-controller",
+	).Return(&gogithub.Repository{Name: stringPtr("ack-s3-controller")}, nil)
+	fakeGithubClient.On(
+		"GetUserRepositoryFork",
+		testingCtx,
+		"ack-bot",
+		"ecr-controller",
+		"ack-ecr-controller",
+	).Return(nil, errors.New("unknown error"))
+	fakeGithubClient.On(
+		"GetUserRepositoryFork",
+		testingCtx,
+		"ack-bot",
+		"sqs-controller",
+		"ack-sqs-controller",
+	).Return(&gogithub.Repository{Name: stringPtr("ack-sqs-controller")}, nil)
+
+	m := &Manager{
+		cfg:        testutil.NewConfig("s3", "ecr", "sqs"),
+		ghc:        fakeGithubClient,
+		urlBuilder: httpsRemoteURLBuilder("github.com"),
+		repoCache: map[string]*Repository{
+			"s3": {
+				gitRepo:          s3Repo,
+				Name:             "s3-controller",
+				ExpectedForkName: "ack-s3-controller",
+			},
+			"ecr": {
+				Name:             "ecr-controller",
+				ExpectedForkName: "ack-ecr-controller",
+			},
+			"sqs": {
+				gitRepo:          sqsRepo,
+				Name:             "sqs-controller",
+				ExpectedForkName: "ack-sqs-controller",
+			},
+		},
+	}
+
+	results := m.EnsureAll(testingCtx, 2)
+	require.Len(results, 3)
+
+	assert.Equal("s3-controller", results[0].Repository.Name)
+	assert.False(results[0].Failed())
+	assert.Equal("ecr-controller", results[1].Repository.Name)
+	assert.True(results[1].Failed())
+	assert.Equal(EnsureStepFork, results[1].Step)
+	assert.Equal("sqs-controller", results[2].Repository.Name)
+	assert.False(results[2].Failed())
+
+	failed := results.Failed()
+	require.Len(failed, 1)
+	assert.Equal("ecr-controller", failed[0].Repository.Name)
+
+	remotes, err := s3Repo.Remotes()
+	require.NoError(err)
+	assert.Len(remotes, 2)
+}
+
+func notFoundError() error {
+	return &gogithub.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusNotFound},
+	}
+}
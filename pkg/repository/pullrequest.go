@@ -0,0 +1,359 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"text/template"
+
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	gogithub "github.com/google/go-github/v61/github"
+)
+
+const (
+	// DefaultBaseBranch is the upstream branch pull requests are opened against.
+	DefaultBaseBranch = "main"
+
+	ReviewStateApproved         = "APPROVED"
+	ReviewStateChangesRequested = "CHANGES_REQUESTED"
+	ReviewStatePending          = "PENDING"
+
+	CheckStatusSuccess = "success"
+	CheckStatusFailure = "failure"
+	CheckStatusPending = "pending"
+	CheckStatusNone    = "none"
+
+	mergeableStateUnknown = "unknown"
+)
+
+var (
+	ErrDetachedHead = errors.New("HEAD is detached")
+)
+
+// PullRequestOptions contains the options used to open a pull request.
+type PullRequestOptions struct {
+	// Base is the upstream branch the pull request is opened against.
+	// Defaults to DefaultBaseBranch.
+	Base string
+	// Title is the pull request title. Defaults to the latest commit subject.
+	Title string
+	// Body is the pull request body. Defaults to the latest commit body or
+	// to the rendered Template.
+	Body string
+	// Template is the path of a text/template file used to render the pull
+	// request body. It is executed with a PullRequestTemplateData.
+	Template string
+	// Draft tells whether the pull request should be opened as a draft.
+	Draft bool
+	// Reviewers is the list of Github users requested for review.
+	Reviewers []string
+}
+
+// PullRequestTemplateData is the data passed to pull request body templates.
+type PullRequestTemplateData struct {
+	// Repository is the upstream repository name
+	Repository string
+	// Branch is the pull request head branch
+	Branch string
+	// Title is the latest commit subject
+	Title string
+	// Body is the latest commit body
+	Body string
+}
+
+// CreatePullRequest pushes the current branch of a repository to origin, if
+// needed, and opens a pull request against the upstream repository.
+func (m *Manager) CreatePullRequest(ctx context.Context, repo *Repository, opts PullRequestOptions) (*gogithub.PullRequest, error) {
+	if repo.gitRepo == nil {
+		return nil, ErrRepositoryDoesntExist
+	}
+	base := opts.Base
+	if base == "" {
+		base = DefaultBaseBranch
+	}
+
+	head, err := repo.gitRepo.Head()
+	if err != nil {
+		return nil, err
+	}
+	if !head.Name().IsBranch() {
+		return nil, ErrDetachedHead
+	}
+	branch := head.Name().Short()
+	if branch == base {
+		return nil, fmt.Errorf("cannot open a pull request from the base branch %s", base)
+	}
+
+	err = m.ensureBranchPushed(ctx, repo, head)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.gitRepo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	data := PullRequestTemplateData{
+		Repository: repo.Name,
+		Branch:     branch,
+	}
+	data.Title, data.Body = splitCommitMessage(commit.Message)
+
+	title := opts.Title
+	if title == "" {
+		title = data.Title
+	}
+	body := opts.Body
+	if body == "" {
+		body = data.Body
+		if opts.Template != "" {
+			body, err = renderPullRequestTemplate(opts.Template, data)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	pr, err := m.ghc.CreatePullRequest(ctx, repo.Name, &gogithub.NewPullRequest{
+		Title: &title,
+		Head:  gogithub.String(fmt.Sprintf("%s:%s", m.cfg.Github.GetForkOwner(), branch)),
+		Base:  &base,
+		Body:  &body,
+		Draft: &opts.Draft,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create pull request: %v", err)
+	}
+
+	if len(opts.Reviewers) > 0 {
+		err = m.ghc.RequestReviewers(ctx, repo.Name, pr.GetNumber(), opts.Reviewers)
+		if err != nil {
+			return pr, fmt.Errorf("cannot request reviewers: %v", err)
+		}
+	}
+	return pr, nil
+}
+
+// ensureBranchPushed pushes a local branch to origin if the remote branch doesn't
+// exist or doesn't point to the same commit.
+func (m *Manager) ensureBranchPushed(ctx context.Context, repo *Repository, head *plumbing.Reference) error {
+	remoteRef, err := repo.gitRepo.Reference(plumbing.NewRemoteReferenceName(originRemoteName, head.Name().Short()), true)
+	if err == nil && remoteRef.Hash() == head.Hash() {
+		return nil
+	}
+	if err != nil && err != plumbing.ErrReferenceNotFound {
+		return err
+	}
+
+	refSpec := gitconfig.RefSpec(fmt.Sprintf("%s:%s", head.Name(), head.Name()))
+	err = m.gitRemote.Push(ctx, repo.gitRepo, originRemoteName, refSpec)
+	if err != nil {
+		return fmt.Errorf("cannot push to %s: %v", originRemoteName, err)
+	}
+	return nil
+}
+
+// splitCommitMessage returns the subject and the body of a commit message.
+func splitCommitMessage(message string) (string, string) {
+	subject, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(subject), strings.TrimSpace(body)
+}
+
+// renderPullRequestTemplate renders a pull request body template.
+func renderPullRequestTemplate(path string, data PullRequestTemplateData) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New("pull-request").Parse(string(content))
+	if err != nil {
+		return "", fmt.Errorf("cannot parse pull request template: %v", err)
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	if err != nil {
+		return "", fmt.Errorf("cannot render pull request template: %v", err)
+	}
+	return buf.String(), nil
+}
+
+// PullRequestInfo summarises the state of an open pull request.
+type PullRequestInfo struct {
+	// Repository is the upstream repository name
+	Repository string `json:"repository"`
+	// Number is the pull request number
+	Number int `json:"number"`
+	// Title is the pull request title
+	Title string `json:"title"`
+	// URL is the pull request web page URL
+	URL string `json:"url"`
+	// Author is the pull request author Github login
+	Author string `json:"author"`
+	// Branch is the pull request head branch
+	Branch string `json:"branch"`
+	// Draft tells whether the pull request is a draft
+	Draft bool `json:"draft"`
+	// ReviewState is the aggregated review state: APPROVED, CHANGES_REQUESTED
+	// or PENDING
+	ReviewState string `json:"reviewState"`
+	// Mergeable is the Github mergeable state (clean, dirty, blocked...)
+	Mergeable string `json:"mergeable"`
+	// CheckStatus is the combined status of the commit statuses and check runs
+	// of the pull request head commit: success, failure, pending or none.
+	CheckStatus string `json:"checkStatus"`
+}
+
+// ListPullRequests lists the open pull requests opened by author against the
+// upstream repositories. Repositories are queried in parallel using at most
+// maxWorkers goroutines. The returned pull requests are in the same order as
+// repos.
+func (m *Manager) ListPullRequests(ctx context.Context, repos []*Repository, author string, maxWorkers int) ([]*PullRequestInfo, error) {
+	perRepo := make([][]*PullRequestInfo, len(repos))
+	var mu sync.Mutex
+	var errs []error
+	ForEach(repos, maxWorkers, func(i int, repo *Repository) {
+		infos, err := m.listRepositoryPullRequests(ctx, repo, author)
+		if err != nil {
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("cannot list %s pull requests: %v", repo.Name, err))
+			mu.Unlock()
+			return
+		}
+		perRepo[i] = infos
+	})
+
+	infos := []*PullRequestInfo{}
+	for _, repoInfos := range perRepo {
+		infos = append(infos, repoInfos...)
+	}
+	return infos, errors.Join(errs...)
+}
+
+func (m *Manager) listRepositoryPullRequests(ctx context.Context, repo *Repository, author string) ([]*PullRequestInfo, error) {
+	prs, err := m.ghc.ListPullRequests(ctx, repo.Name, author)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*PullRequestInfo, 0, len(prs))
+	for _, pr := range prs {
+		// The list endpoint doesn't compute the pull requests mergeability
+		pr, err = m.ghc.GetPullRequest(ctx, repo.Name, pr.GetNumber())
+		if err != nil {
+			return nil, err
+		}
+		reviews, err := m.ghc.ListPullRequestReviews(ctx, repo.Name, pr.GetNumber())
+		if err != nil {
+			return nil, err
+		}
+		headSHA := pr.GetHead().GetSHA()
+		status, err := m.ghc.GetCombinedStatus(ctx, repo.Name, headSHA)
+		if err != nil {
+			return nil, err
+		}
+		checkRuns, err := m.ghc.ListCheckRuns(ctx, repo.Name, headSHA)
+		if err != nil {
+			return nil, err
+		}
+
+		mergeable := pr.GetMergeableState()
+		if mergeable == "" {
+			mergeable = mergeableStateUnknown
+		}
+		infos = append(infos, &PullRequestInfo{
+			Repository:  repo.Name,
+			Number:      pr.GetNumber(),
+			Title:       pr.GetTitle(),
+			URL:         pr.GetHTMLURL(),
+			Author:      pr.GetUser().GetLogin(),
+			Branch:      pr.GetHead().GetRef(),
+			Draft:       pr.GetDraft(),
+			ReviewState: aggregateReviewState(reviews),
+			Mergeable:   mergeable,
+			CheckStatus: combineCheckStatus(status, checkRuns),
+		})
+	}
+	return infos, nil
+}
+
+// aggregateReviewState computes a pull request review state from the latest
+// review of each reviewer. Comments don't affect the review state.
+func aggregateReviewState(reviews []*gogithub.PullRequestReview) string {
+	latest := map[string]string{}
+	for _, review := range reviews {
+		state := review.GetState()
+		if state != ReviewStateApproved && state != ReviewStateChangesRequested && state != "DISMISSED" {
+			continue
+		}
+		latest[review.GetUser().GetLogin()] = state
+	}
+
+	approved := false
+	for _, state := range latest {
+		switch state {
+		case ReviewStateChangesRequested:
+			return ReviewStateChangesRequested
+		case ReviewStateApproved:
+			approved = true
+		}
+	}
+	if approved {
+		return ReviewStateApproved
+	}
+	return ReviewStatePending
+}
+
+// combineCheckStatus combines the commit statuses and the check runs of a commit.
+// It returns failure if any of them failed, pending if any of them is still running
+// and success if all of them succeeded.
+func combineCheckStatus(status *gogithub.CombinedStatus, checkRuns []*gogithub.CheckRun) string {
+	var states []string
+	if status.GetTotalCount() > 0 {
+		states = append(states, status.GetState())
+	}
+	for _, run := range checkRuns {
+		if run.GetStatus() != "completed" {
+			states = append(states, CheckStatusPending)
+			continue
+		}
+		switch run.GetConclusion() {
+		case "success", "neutral", "skipped":
+			states = append(states, CheckStatusSuccess)
+		default:
+			states = append(states, CheckStatusFailure)
+		}
+	}
+
+	if len(states) == 0 {
+		return CheckStatusNone
+	}
+	combined := CheckStatusSuccess
+	for _, state := range states {
+		switch state {
+		case CheckStatusSuccess:
+		case CheckStatusPending:
+			combined = CheckStatusPending
+		default:
+			return CheckStatusFailure
+		}
+	}
+	return combined
+}
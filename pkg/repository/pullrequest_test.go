@@ -0,0 +1,270 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gogithub "github.com/google/go-github/v61/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dev-tools/mocks"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/testutil"
+)
+
+func TestManager_CreatePullRequest(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	baseRepo, err := testutil.NewInMemoryGitRepository()
+	require.NoError(err)
+
+	featureRepo, err := testutil.NewInMemoryGitRepository()
+	require.NoError(err)
+	worktree, err := featureRepo.Worktree()
+	require.NoError(err)
+	require.NoError(worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName("feature"),
+		Create: true,
+	}))
+	_, err = worktree.Commit("Add feature\n\nSome feature details\n", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            &object.Signature{Name: "ack-bot", Email: "ack-bot@ack"},
+	})
+	require.NoError(err)
+
+	templatePath := filepath.Join(t.TempDir(), "template.md")
+	require.NoError(os.WriteFile(templatePath, []byte("Branch: {{ .Branch }}\n{{ .Body }}"), 0644))
+
+	fakeRemote := &mocks.FetchPusher{}
+	fakeRemote.On(
+		"Push",
+		testingCtx,
+		featureRepo,
+		originRemoteName,
+		gitconfig.RefSpec("refs/heads/feature:refs/heads/feature"),
+	).Return(nil)
+
+	fakeGithubClient := &mocks.RepositoryService{}
+	fakeGithubClient.On("CreatePullRequest", testingCtx, "runtime", &gogithub.NewPullRequest{
+		Title: gogithub.String("Add feature"),
+		Head:  gogithub.String("ack-bot:feature"),
+		Base:  gogithub.String("main"),
+		Body:  gogithub.String("Some feature details"),
+		Draft: gogithub.Bool(true),
+	}).Return(&gogithub.PullRequest{Number: gogithub.Int(42)}, nil)
+	fakeGithubClient.On("CreatePullRequest", testingCtx, "runtime", &gogithub.NewPullRequest{
+		Title: gogithub.String("Custom title"),
+		Head:  gogithub.String("ack-bot:feature"),
+		Base:  gogithub.String("release"),
+		Body:  gogithub.String("Branch: feature\nSome feature details"),
+		Draft: gogithub.Bool(false),
+	}).Return(&gogithub.PullRequest{Number: gogithub.Int(43)}, nil)
+	fakeGithubClient.On("RequestReviewers", testingCtx, "runtime", 42, []string{"a-hilaly"}).Return(nil)
+
+	tests := []struct {
+		name       string
+		repo       *Repository
+		opts       PullRequestOptions
+		wantNumber int
+		wantErr    bool
+	}{
+		{
+			name:    "repository not cloned",
+			repo:    &Repository{Name: "runtime"},
+			wantErr: true,
+		},
+		{
+			name:    "pull request from base branch",
+			repo:    &Repository{Name: "runtime", gitRepo: baseRepo},
+			opts:    PullRequestOptions{Base: "master"},
+			wantErr: true,
+		},
+		{
+			name:       "pull request from latest commit",
+			repo:       &Repository{Name: "runtime", gitRepo: featureRepo},
+			opts:       PullRequestOptions{Draft: true, Reviewers: []string{"a-hilaly"}},
+			wantNumber: 42,
+		},
+		{
+			name:       "pull request from template",
+			repo:       &Repository{Name: "runtime", gitRepo: featureRepo},
+			opts:       PullRequestOptions{Base: "release", Title: "Custom title", Template: templatePath},
+			wantNumber: 43,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manager{
+				cfg:       testutil.NewConfig(),
+				ghc:       fakeGithubClient,
+				gitRemote: fakeRemote,
+			}
+			pr, err := m.CreatePullRequest(testingCtx, tt.repo, tt.opts)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantNumber, pr.GetNumber())
+		})
+	}
+}
+
+func TestManager_ListPullRequests(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	fakeGithubClient := &mocks.RepositoryService{}
+	fakeGithubClient.On("ListPullRequests", testingCtx, "runtime", "ack-bot").Return([]*gogithub.PullRequest{
+		{Number: gogithub.Int(42)},
+	}, nil)
+	fakeGithubClient.On("GetPullRequest", testingCtx, "runtime", 42).Return(&gogithub.PullRequest{
+		Number:         gogithub.Int(42),
+		Title:          gogithub.String("Add feature"),
+		User:           &gogithub.User{Login: gogithub.String("ack-bot")},
+		Head:           &gogithub.PullRequestBranch{Ref: gogithub.String("feature"), SHA: gogithub.String("1729")},
+		MergeableState: gogithub.String("clean"),
+	}, nil)
+	fakeGithubClient.On("ListPullRequestReviews", testingCtx, "runtime", 42).Return([]*gogithub.PullRequestReview{
+		{User: &gogithub.User{Login: gogithub.String("a-hilaly")}, State: gogithub.String("APPROVED")},
+	}, nil)
+	fakeGithubClient.On("GetCombinedStatus", testingCtx, "runtime", "1729").Return(&gogithub.CombinedStatus{
+		TotalCount: gogithub.Int(1),
+		State:      gogithub.String("pending"),
+	}, nil)
+	fakeGithubClient.On("ListCheckRuns", testingCtx, "runtime", "1729").Return([]*gogithub.CheckRun{}, nil)
+	fakeGithubClient.On("ListPullRequests", testingCtx, "code-generator", "ack-bot").Return(nil, errors.New("unknown error"))
+	fakeGithubClient.On("ListPullRequests", testingCtx, "s3-controller", "ack-bot").Return([]*gogithub.PullRequest{}, nil)
+
+	m := &Manager{
+		cfg: testutil.NewConfig(),
+		ghc: fakeGithubClient,
+	}
+	repos := []*Repository{{Name: "runtime"}, {Name: "code-generator"}, {Name: "s3-controller"}}
+	prs, err := m.ListPullRequests(testingCtx, repos, "ack-bot", 2)
+	assert.ErrorContains(err, "code-generator")
+	require.Len(prs, 1)
+	assert.Equal(&PullRequestInfo{
+		Repository:  "runtime",
+		Number:      42,
+		Title:       "Add feature",
+		Author:      "ack-bot",
+		Branch:      "feature",
+		ReviewState: ReviewStateApproved,
+		Mergeable:   "clean",
+		CheckStatus: CheckStatusPending,
+	}, prs[0])
+}
+
+func Test_aggregateReviewState(t *testing.T) {
+	review := func(user, state string) *gogithub.PullRequestReview {
+		return &gogithub.PullRequestReview{
+			User:  &gogithub.User{Login: gogithub.String(user)},
+			State: gogithub.String(state),
+		}
+	}
+	tests := []struct {
+		name    string
+		reviews []*gogithub.PullRequestReview
+		want    string
+	}{
+		{
+			name: "no reviews",
+			want: ReviewStatePending,
+		},
+		{
+			name:    "only comments",
+			reviews: []*gogithub.PullRequestReview{review("a", "COMMENTED")},
+			want:    ReviewStatePending,
+		},
+		{
+			name:    "approved after changes requested",
+			reviews: []*gogithub.PullRequestReview{review("a", "CHANGES_REQUESTED"), review("a", "APPROVED")},
+			want:    ReviewStateApproved,
+		},
+		{
+			name:    "changes requested by another reviewer",
+			reviews: []*gogithub.PullRequestReview{review("a", "APPROVED"), review("b", "CHANGES_REQUESTED")},
+			want:    ReviewStateChangesRequested,
+		},
+		{
+			name:    "dismissed approval",
+			reviews: []*gogithub.PullRequestReview{review("a", "APPROVED"), review("a", "DISMISSED")},
+			want:    ReviewStatePending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, aggregateReviewState(tt.reviews))
+		})
+	}
+}
+
+func Test_combineCheckStatus(t *testing.T) {
+	checkRun := func(status, conclusion string) *gogithub.CheckRun {
+		return &gogithub.CheckRun{Status: gogithub.String(status), Conclusion: gogithub.String(conclusion)}
+	}
+	combined := func(state string) *gogithub.CombinedStatus {
+		return &gogithub.CombinedStatus{TotalCount: gogithub.Int(1), State: gogithub.String(state)}
+	}
+	tests := []struct {
+		name      string
+		status    *gogithub.CombinedStatus
+		checkRuns []*gogithub.CheckRun
+		want      string
+	}{
+		{
+			name:   "no checks",
+			status: &gogithub.CombinedStatus{TotalCount: gogithub.Int(0), State: gogithub.String("pending")},
+			want:   CheckStatusNone,
+		},
+		{
+			name:      "all succeeded",
+			status:    combined("success"),
+			checkRuns: []*gogithub.CheckRun{checkRun("completed", "success"), checkRun("completed", "skipped")},
+			want:      CheckStatusSuccess,
+		},
+		{
+			name:      "check run in progress",
+			status:    combined("success"),
+			checkRuns: []*gogithub.CheckRun{checkRun("in_progress", "")},
+			want:      CheckStatusPending,
+		},
+		{
+			name:      "failed check run",
+			status:    combined("pending"),
+			checkRuns: []*gogithub.CheckRun{checkRun("completed", "timed_out")},
+			want:      CheckStatusFailure,
+		},
+		{
+			name:   "failed commit status",
+			status: combined("error"),
+			want:   CheckStatusFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, combineCheckStatus(tt.status, tt.checkRuns))
+		})
+	}
+}
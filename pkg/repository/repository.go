@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 )
 
 // NewRepository returns a pointer to a new repository.
@@ -34,6 +35,9 @@ func NewRepository(name string, repoType RepositoryType) *Repository {
 type Repository struct {
 	// this field might be nil, if the repository doesn't exist locally
 	gitRepo *git.Repository
+	// branchedFrom is the HEAD reference preceding the last CreateBranch call,
+	// used by AbortBranch.
+	branchedFrom *plumbing.Reference
 
 	// Name of the ACK upstream repo
 	Name string
@@ -47,10 +51,18 @@ type Repository struct {
 	GitHead string
 }
 
-func httpsRemoteURL(owner, name string) string {
-	return fmt.Sprintf("https://github.com/%s/%s.git", owner, name)
+// httpsRemoteURLBuilder returns a function building HTTPS remote URLs for the
+// given git host.
+func httpsRemoteURLBuilder(host string) func(owner, name string) string {
+	return func(owner, name string) string {
+		return fmt.Sprintf("https://%s/%s/%s.git", host, owner, name)
+	}
 }
 
-func sshRemoteURL(owner, name string) string {
-	return fmt.Sprintf("git@github.com:%s/%s.git", owner, name)
+// sshRemoteURLBuilder returns a function building SSH remote URLs for the
+// given git host.
+func sshRemoteURLBuilder(host string) func(owner, name string) string {
+	return func(owner, name string) string {
+		return fmt.Sprintf("git@%s:%s/%s.git", host, owner, name)
+	}
 }
@@ -0,0 +1,41 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_remoteURLBuilders(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(
+		"https://github.com/aws-controllers-k8s/runtime.git",
+		httpsRemoteURLBuilder("github.com")("aws-controllers-k8s", "runtime"),
+	)
+	assert.Equal(
+		"git@github.com:ack-bot/ack-runtime.git",
+		sshRemoteURLBuilder("github.com")("ack-bot", "ack-runtime"),
+	)
+	assert.Equal(
+		"https://github.example.com/ack-mirror/runtime.git",
+		httpsRemoteURLBuilder("github.example.com")("ack-mirror", "runtime"),
+	)
+	assert.Equal(
+		"git@github.example.com:ack-bot/ack-runtime.git",
+		sshRemoteURLBuilder("github.example.com")("ack-bot", "ack-runtime"),
+	)
+}
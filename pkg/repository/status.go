@@ -0,0 +1,269 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+const (
+	stashReferenceName = plumbing.ReferenceName("refs/stash")
+	stashReflogPath    = "logs/refs/stash"
+)
+
+// Divergence represents the number of commits a local branch is ahead and
+// behind its remote counterpart.
+type Divergence struct {
+	Ahead  int
+	Behind int
+}
+
+// String returns a short representation of the divergence. e.g +2/-1
+func (d *Divergence) String() string {
+	return fmt.Sprintf("+%d/-%d", d.Ahead, d.Behind)
+}
+
+// Status represents the state of a local repository.
+type Status struct {
+	// Branch is the current branch name. Empty if HEAD is detached.
+	Branch string
+	// Modified is the number of tracked files with staged or unstaged changes.
+	Modified int
+	// Untracked is the number of untracked files.
+	Untracked int
+	// Origin is the divergence between the current branch and its origin
+	// counterpart. nil if the remote branch doesn't exist.
+	Origin *Divergence
+	// Upstream is the divergence between the current branch and its upstream
+	// counterpart. nil if the remote branch doesn't exist.
+	Upstream *Divergence
+	// Stashes is the number of stash entries.
+	Stashes int
+}
+
+// Dirty returns true if the repository contains uncommitted changes or
+// untracked files.
+func (s *Status) Dirty() bool {
+	return s.Modified > 0 || s.Untracked > 0
+}
+
+// Status computes the status of the local repository. It returns
+// ErrRepositoryDoesntExist if the repository isn't cloned.
+func (r *Repository) Status() (*Status, error) {
+	if r.gitRepo == nil {
+		return nil, ErrRepositoryDoesntExist
+	}
+
+	status := &Status{}
+	worktree, err := r.gitRepo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	wStatus, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("cannot compute worktree status: %v", err)
+	}
+	for _, fileStatus := range wStatus {
+		if fileStatus.Worktree == git.Untracked {
+			status.Untracked++
+			continue
+		}
+		if fileStatus.Worktree != git.Unmodified || fileStatus.Staging != git.Unmodified {
+			status.Modified++
+		}
+	}
+
+	head, err := r.gitRepo.Head()
+	if err != nil {
+		return nil, err
+	}
+	if head.Name().IsBranch() {
+		status.Branch = head.Name().Short()
+
+		status.Origin, err = r.divergence(head.Hash(), originRemoteName, status.Branch)
+		if err != nil {
+			return nil, err
+		}
+		status.Upstream, err = r.divergence(head.Hash(), upstreamRemoteName, status.Branch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	status.Stashes, err = r.countStashes()
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// FileChange represents an uncommitted change of a file.
+type FileChange struct {
+	// Path is the file path relative to the repository root
+	Path string
+	// Change is the kind of change, e.g modified, added, untracked...
+	Change string
+}
+
+// ChangedFiles returns the uncommitted changes of the local repository, sorted
+// by path. It returns ErrRepositoryDoesntExist if the repository isn't cloned.
+func (r *Repository) ChangedFiles() ([]*FileChange, error) {
+	if r.gitRepo == nil {
+		return nil, ErrRepositoryDoesntExist
+	}
+
+	worktree, err := r.gitRepo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	wStatus, err := worktree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("cannot compute worktree status: %v", err)
+	}
+
+	changes := []*FileChange{}
+	for path, fileStatus := range wStatus {
+		code := fileStatus.Worktree
+		if code == git.Unmodified {
+			code = fileStatus.Staging
+		}
+		if code == git.Unmodified {
+			continue
+		}
+		changes = append(changes, &FileChange{Path: path, Change: changeName(code)})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// changeName returns a human readable name of a git status code.
+func changeName(code git.StatusCode) string {
+	switch code {
+	case git.Untracked:
+		return "untracked"
+	case git.Modified:
+		return "modified"
+	case git.Added:
+		return "added"
+	case git.Deleted:
+		return "deleted"
+	case git.Renamed:
+		return "renamed"
+	case git.Copied:
+		return "copied"
+	case git.UpdatedButUnmerged:
+		return "unmerged"
+	}
+	return "unknown"
+}
+
+// divergence computes the number of commits the local commit is ahead and
+// behind a remote branch. It returns nil if the remote branch doesn't exist.
+func (r *Repository) divergence(local plumbing.Hash, remote, branch string) (*Divergence, error) {
+	remoteRef, err := r.gitRepo.Reference(plumbing.NewRemoteReferenceName(remote, branch), true)
+	if err == plumbing.ErrReferenceNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	localCommit, err := r.gitRepo.CommitObject(local)
+	if err != nil {
+		return nil, err
+	}
+	remoteCommit, err := r.gitRepo.CommitObject(remoteRef.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	bases, err := localCommit.MergeBase(remoteCommit)
+	if err != nil {
+		return nil, err
+	}
+	ignore := make([]plumbing.Hash, 0, len(bases))
+	for _, base := range bases {
+		ignore = append(ignore, base.Hash)
+	}
+
+	ahead, err := countCommits(localCommit, ignore)
+	if err != nil {
+		return nil, err
+	}
+	behind, err := countCommits(remoteCommit, ignore)
+	if err != nil {
+		return nil, err
+	}
+	return &Divergence{Ahead: ahead, Behind: behind}, nil
+}
+
+// countCommits counts the commits reachable from c without going through
+// the ignored commits.
+func countCommits(c *object.Commit, ignore []plumbing.Hash) (int, error) {
+	count := 0
+	err := object.NewCommitPreorderIter(c, nil, ignore).ForEach(func(*object.Commit) error {
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// countStashes returns the number of stash entries. go-git doesn't support
+// stashes, so the entries are counted from the stash reflog when the repository
+// is stored on a filesystem.
+func (r *Repository) countStashes() (int, error) {
+	_, err := r.gitRepo.Reference(stashReferenceName, false)
+	if err == plumbing.ErrReferenceNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	storage, ok := r.gitRepo.Storer.(*filesystem.Storage)
+	if !ok {
+		// Only the latest stash is visible without a reflog
+		return 1, nil
+	}
+	f, err := storage.Filesystem().Open(stashReflogPath)
+	if os.IsNotExist(err) {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return countLines(f)
+}
+
+func countLines(r io.Reader) (int, error) {
+	count := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		count++
+	}
+	return count, scanner.Err()
+}
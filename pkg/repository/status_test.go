@@ -0,0 +1,123 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/testutil"
+)
+
+func TestRepository_Status(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, err := (&Repository{Name: "s3-controller"}).Status()
+	assert.Equal(ErrRepositoryDoesntExist, err)
+
+	gitRepo, err := testutil.NewInMemoryGitRepository()
+	require.NoError(err)
+	repo := &Repository{Name: "runtime", gitRepo: gitRepo}
+
+	status, err := repo.Status()
+	require.NoError(err)
+	assert.Equal("master", status.Branch)
+	assert.False(status.Dirty())
+	assert.Nil(status.Origin)
+	assert.Nil(status.Upstream)
+	assert.Equal(0, status.Stashes)
+
+	head, err := gitRepo.Head()
+	require.NoError(err)
+	// upstream is at the first commit, origin will be one commit ahead
+	// of the local branch.
+	require.NoError(gitRepo.Storer.SetReference(plumbing.NewHashReference(
+		plumbing.NewRemoteReferenceName(upstreamRemoteName, "master"), head.Hash(),
+	)))
+
+	worktree, err := gitRepo.Worktree()
+	require.NoError(err)
+	commit := func(msg string) plumbing.Hash {
+		h, err := worktree.Commit(msg, &git.CommitOptions{
+			AllowEmptyCommits: true,
+			Author:            &object.Signature{Name: "ack-bot", Email: "ack-bot@ack"},
+		})
+		require.NoError(err)
+		return h
+	}
+	commit("second commit")
+	originHash := commit("third commit")
+	require.NoError(gitRepo.Storer.SetReference(plumbing.NewHashReference(
+		plumbing.NewRemoteReferenceName(originRemoteName, "master"), originHash,
+	)))
+	require.NoError(worktree.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.HardReset}))
+	commit("fourth commit")
+
+	fs := worktree.Filesystem
+	require.NoError(util.WriteFile(fs, "ramanujan_serie.txt", []byte("1 + 1 = 2"), 0644))
+	require.NoError(util.WriteFile(fs, "untracked.txt", []byte("untracked"), 0644))
+	require.NoError(gitRepo.Storer.SetReference(plumbing.NewHashReference(stashReferenceName, head.Hash())))
+
+	status, err = repo.Status()
+	require.NoError(err)
+	assert.True(status.Dirty())
+	assert.Equal(1, status.Modified)
+	assert.Equal(1, status.Untracked)
+	require.NotNil(status.Upstream)
+	assert.Equal(Divergence{Ahead: 1, Behind: 0}, *status.Upstream)
+	require.NotNil(status.Origin)
+	assert.Equal(Divergence{Ahead: 1, Behind: 2}, *status.Origin)
+	assert.Equal("+1/-2", status.Origin.String())
+	assert.Equal(1, status.Stashes)
+}
+
+func TestRepository_ChangedFiles(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, err := (&Repository{Name: "s3-controller"}).ChangedFiles()
+	assert.Equal(ErrRepositoryDoesntExist, err)
+
+	gitRepo, err := testutil.NewInMemoryGitRepository()
+	require.NoError(err)
+	repo := &Repository{Name: "s3-controller", gitRepo: gitRepo}
+
+	changes, err := repo.ChangedFiles()
+	require.NoError(err)
+	assert.Empty(changes)
+
+	worktree, err := gitRepo.Worktree()
+	require.NoError(err)
+	fs := worktree.Filesystem
+	require.NoError(util.WriteFile(fs, "ramanujan_serie.txt", []byte("1 + 1 = 2"), 0644))
+	require.NoError(util.WriteFile(fs, "apis/v1alpha1/bucket.go", []byte("package v1alpha1"), 0644))
+	require.NoError(util.WriteFile(fs, "staged.txt", []byte("staged"), 0644))
+	_, err = worktree.Add("staged.txt")
+	require.NoError(err)
+
+	changes, err = repo.ChangedFiles()
+	require.NoError(err)
+	assert.Equal([]*FileChange{
+		{Path: "apis/v1alpha1/bucket.go", Change: "untracked"},
+		{Path: "ramanujan_serie.txt", Change: "modified"},
+		{Path: "staged.txt", Change: "added"},
+	}, changes)
+}
@@ -0,0 +1,209 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	git "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+const (
+	// DefaultSyncBranch is the branch synchronised by default. All ACK
+	// repositories use main as their default branch.
+	DefaultSyncBranch = "main"
+)
+
+// SyncStatus is the outcome of synchronising a repository.
+type SyncStatus string
+
+const (
+	// SyncStatusUpdated means that the local branch was fast-forwarded.
+	SyncStatusUpdated SyncStatus = "UPDATED"
+	// SyncStatusUpToDate means that the local branch already points to the
+	// upstream branch.
+	SyncStatusUpToDate SyncStatus = "UP-TO-DATE"
+	// SyncStatusSkipped means that the local branch couldn't be safely
+	// fast-forwarded.
+	SyncStatusSkipped SyncStatus = "SKIPPED"
+	// SyncStatusFailed means that an error occurred while synchronising.
+	SyncStatusFailed SyncStatus = "FAILED"
+)
+
+// SyncOptions contains the options used to synchronise repositories.
+type SyncOptions struct {
+	// Branch is the local branch fast-forwarded to its upstream counterpart.
+	// Defaults to DefaultSyncBranch.
+	Branch string
+	// Push tells whether the synchronised branch should be pushed to the
+	// origin remote (the user fork).
+	Push bool
+}
+
+// SyncResult is the outcome of synchronising a single repository.
+type SyncResult struct {
+	// Repository is the synchronised repository
+	Repository *Repository
+	// Status is the outcome of the synchronisation
+	Status SyncStatus
+	// Reason explains why a repository was skipped
+	Reason string
+	// Pushed tells whether the branch was pushed to origin
+	Pushed bool
+	// Err is the error that made the synchronisation fail
+	Err error
+}
+
+// SyncResults is the list of results returned by Manager.SyncAll
+type SyncResults []*SyncResult
+
+// Failed returns the results of the repositories that failed to synchronise.
+func (rs SyncResults) Failed() SyncResults {
+	failed := SyncResults{}
+	for _, r := range rs {
+		if r.Status == SyncStatusFailed {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// SyncAll synchronises the given repositories using at most maxWorkers
+// goroutines. The returned results are in the same order as repos.
+func (m *Manager) SyncAll(ctx context.Context, repos []*Repository, opts SyncOptions, maxWorkers int) SyncResults {
+	results := make(SyncResults, len(repos))
+	ForEach(repos, maxWorkers, func(i int, repo *Repository) {
+		results[i] = m.Sync(ctx, repo, opts)
+	})
+	return results
+}
+
+// Sync fetches the upstream remote of a repository and fast-forwards the local
+// branch to its upstream counterpart. The branch is only updated if it's strictly
+// behind upstream and, when it's checked out, if the worktree is clean.
+func (m *Manager) Sync(ctx context.Context, repo *Repository, opts SyncOptions) *SyncResult {
+	result := &SyncResult{Repository: repo}
+	skip := func(reason string) *SyncResult {
+		result.Status = SyncStatusSkipped
+		result.Reason = reason
+		return result
+	}
+	fail := func(err error) *SyncResult {
+		result.Status = SyncStatusFailed
+		result.Err = err
+		return result
+	}
+
+	if repo.gitRepo == nil {
+		return skip("repository not cloned")
+	}
+	branch := opts.Branch
+	if branch == "" {
+		branch = DefaultSyncBranch
+	}
+
+	err := m.gitRemote.Fetch(ctx, repo.gitRepo, upstreamRemoteName)
+	if err != nil {
+		return fail(fmt.Errorf("cannot fetch %s: %v", upstreamRemoteName, err))
+	}
+
+	upstreamRef, err := repo.gitRepo.Reference(plumbing.NewRemoteReferenceName(upstreamRemoteName, branch), true)
+	if err == plumbing.ErrReferenceNotFound {
+		return skip(fmt.Sprintf("branch %s/%s not found", upstreamRemoteName, branch))
+	}
+	if err != nil {
+		return fail(err)
+	}
+	localRefName := plumbing.NewBranchReferenceName(branch)
+	localRef, err := repo.gitRepo.Reference(localRefName, true)
+	if err == plumbing.ErrReferenceNotFound {
+		return skip(fmt.Sprintf("local branch %s not found", branch))
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	if localRef.Hash() == upstreamRef.Hash() {
+		result.Status = SyncStatusUpToDate
+	} else {
+		reason, err := fastForward(repo.gitRepo, localRef, upstreamRef)
+		if err != nil {
+			return fail(err)
+		}
+		if reason != "" {
+			return skip(reason)
+		}
+		result.Status = SyncStatusUpdated
+	}
+
+	if opts.Push {
+		refSpec := gitconfig.RefSpec(fmt.Sprintf("%s:%s", localRefName, localRefName))
+		err = m.gitRemote.Push(ctx, repo.gitRepo, originRemoteName, refSpec)
+		if err != nil {
+			return fail(fmt.Errorf("cannot push to %s: %v", originRemoteName, err))
+		}
+		result.Pushed = true
+	}
+	return result
+}
+
+// fastForward moves the local branch to the upstream commit. It returns a
+// non empty reason if the fast-forward isn't safe.
+func fastForward(gitRepo *git.Repository, localRef, upstreamRef *plumbing.Reference) (string, error) {
+	localCommit, err := gitRepo.CommitObject(localRef.Hash())
+	if err != nil {
+		return "", err
+	}
+	upstreamCommit, err := gitRepo.CommitObject(upstreamRef.Hash())
+	if err != nil {
+		return "", err
+	}
+	isAncestor, err := localCommit.IsAncestor(upstreamCommit)
+	if err != nil {
+		return "", err
+	}
+	if !isAncestor {
+		return fmt.Sprintf("branch %s diverged from %s", localRef.Name().Short(), upstreamRef.Name().Short()), nil
+	}
+
+	head, err := gitRepo.Head()
+	if err != nil {
+		return "", err
+	}
+	// If the branch isn't checked out, only the reference needs to be updated.
+	if head.Name() != localRef.Name() {
+		return "", gitRepo.Storer.SetReference(plumbing.NewHashReference(localRef.Name(), upstreamRef.Hash()))
+	}
+
+	worktree, err := gitRepo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return "", err
+	}
+	if !status.IsClean() {
+		return "worktree is not clean", nil
+	}
+	// The worktree is clean, resetting it to the upstream commit is equivalent
+	// to a fast-forward merge.
+	return "", worktree.Reset(&git.ResetOptions{
+		Commit: upstreamRef.Hash(),
+		Mode:   git.MergeReset,
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dev-tools/mocks"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/testutil"
+)
+
+// newSyncTestRepository returns an in-memory repository where the upstream
+// master branch is ahead of the local master branch by one commit. If diverged
+// is true, the local branch will also contain a commit unknown to upstream.
+func newSyncTestRepository(t *testing.T, diverged bool) (*git.Repository, plumbing.Hash) {
+	require := require.New(t)
+
+	gitRepo, err := testutil.NewInMemoryGitRepository()
+	require.NoError(err)
+	head, err := gitRepo.Head()
+	require.NoError(err)
+	worktree, err := gitRepo.Worktree()
+	require.NoError(err)
+
+	upstreamHash, err := worktree.Commit("upstream commit", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            &object.Signature{Name: "ack-bot", Email: "ack-bot@ack"},
+	})
+	require.NoError(err)
+	require.NoError(gitRepo.Storer.SetReference(plumbing.NewHashReference(
+		plumbing.NewRemoteReferenceName(upstreamRemoteName, "master"), upstreamHash,
+	)))
+	require.NoError(worktree.Reset(&git.ResetOptions{Commit: head.Hash(), Mode: git.HardReset}))
+
+	if diverged {
+		_, err := worktree.Commit("local commit", &git.CommitOptions{
+			AllowEmptyCommits: true,
+			Author:            &object.Signature{Name: "ack-bot", Email: "ack-bot@ack"},
+		})
+		require.NoError(err)
+	}
+	return gitRepo, upstreamHash
+}
+
+func TestManager_Sync(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	behindRepo, behindUpstreamHash := newSyncTestRepository(t, false)
+	pushedRepo, _ := newSyncTestRepository(t, false)
+	divergedRepo, _ := newSyncTestRepository(t, true)
+	dirtyRepo, _ := newSyncTestRepository(t, false)
+	dirtyWorktree, err := dirtyRepo.Worktree()
+	require.NoError(err)
+	require.NoError(util.WriteFile(dirtyWorktree.Filesystem, "ramanujan_serie.txt", []byte("1 + 1 = 2"), 0644))
+	upToDateRepo, err := testutil.NewInMemoryGitRepository()
+	require.NoError(err)
+	head, err := upToDateRepo.Head()
+	require.NoError(err)
+	require.NoError(upToDateRepo.Storer.SetReference(plumbing.NewHashReference(
+		plumbing.NewRemoteReferenceName(upstreamRemoteName, "master"), head.Hash(),
+	)))
+	fetchErrorRepo, err := testutil.NewInMemoryGitRepository()
+	require.NoError(err)
+
+	fakeRemote := &mocks.FetchPusher{}
+	fakeRemote.On("Fetch", testingCtx, fetchErrorRepo, upstreamRemoteName).Return(errors.New("network error"))
+	fakeRemote.On("Fetch", testingCtx, mock.Anything, upstreamRemoteName).Return(nil)
+	fakeRemote.On(
+		"Push",
+		testingCtx,
+		pushedRepo,
+		originRemoteName,
+		gitconfig.RefSpec("refs/heads/master:refs/heads/master"),
+	).Return(nil)
+
+	tests := []struct {
+		name       string
+		repo       *Repository
+		opts       SyncOptions
+		wantStatus SyncStatus
+		wantPushed bool
+	}{
+		{
+			name:       "repository not cloned",
+			repo:       &Repository{Name: "s3-controller"},
+			opts:       SyncOptions{Branch: "master"},
+			wantStatus: SyncStatusSkipped,
+		},
+		{
+			name:       "fetch error",
+			repo:       &Repository{Name: "ecr-controller", gitRepo: fetchErrorRepo},
+			opts:       SyncOptions{Branch: "master"},
+			wantStatus: SyncStatusFailed,
+		},
+		{
+			name:       "unknown upstream branch",
+			repo:       &Repository{Name: "runtime", gitRepo: behindRepo},
+			wantStatus: SyncStatusSkipped,
+		},
+		{
+			name:       "up to date",
+			repo:       &Repository{Name: "runtime", gitRepo: upToDateRepo},
+			opts:       SyncOptions{Branch: "master"},
+			wantStatus: SyncStatusUpToDate,
+		},
+		{
+			name:       "fast-forward",
+			repo:       &Repository{Name: "runtime", gitRepo: behindRepo},
+			opts:       SyncOptions{Branch: "master"},
+			wantStatus: SyncStatusUpdated,
+		},
+		{
+			name:       "fast-forward and push",
+			repo:       &Repository{Name: "runtime", gitRepo: pushedRepo},
+			opts:       SyncOptions{Branch: "master", Push: true},
+			wantStatus: SyncStatusUpdated,
+			wantPushed: true,
+		},
+		{
+			name:       "diverged branch",
+			repo:       &Repository{Name: "runtime", gitRepo: divergedRepo},
+			opts:       SyncOptions{Branch: "master"},
+			wantStatus: SyncStatusSkipped,
+		},
+		{
+			name:       "dirty worktree",
+			repo:       &Repository{Name: "runtime", gitRepo: dirtyRepo},
+			opts:       SyncOptions{Branch: "master"},
+			wantStatus: SyncStatusSkipped,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manager{
+				cfg:       testutil.NewConfig(),
+				gitRemote: fakeRemote,
+			}
+			result := m.Sync(testingCtx, tt.repo, tt.opts)
+			assert.Equal(tt.wantStatus, result.Status, "reason: %s, error: %v", result.Reason, result.Err)
+			assert.Equal(tt.wantPushed, result.Pushed)
+		})
+	}
+
+	head, err = behindRepo.Head()
+	require.NoError(err)
+	assert.Equal(behindUpstreamHash, head.Hash())
+}
@@ -13,6 +13,15 @@
 
 package repository
 
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownRepositoryType = errors.New("unknown repository type")
+)
+
 type RepositoryType int
 
 const (
@@ -36,7 +45,22 @@ func (rt RepositoryType) String() string {
 	}
 }
 
-// GetRepositoryTypeFromString casts a string to a RepositoryType
+// ParseRepositoryType casts a string to a RepositoryType. It returns
+// ErrUnknownRepositoryType if the string isn't a known repository type.
+func ParseRepositoryType(s string) (RepositoryType, error) {
+	switch s {
+	case "core":
+		return RepositoryTypeCore, nil
+	case "controller":
+		return RepositoryTypeController, nil
+	default:
+		return RepositoryTypeUnknown, fmt.Errorf("%w: %s, expected core or controller", ErrUnknownRepositoryType, s)
+	}
+}
+
+// GetRepositoryTypeFromString casts a string to a RepositoryType. It panics if
+// the string isn't a known repository type, use ParseRepositoryType to parse
+// user input.
 func GetRepositoryTypeFromString(s string) RepositoryType {
 	switch s {
 	case "core":
@@ -0,0 +1,216 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/asyncexec"
+)
+
+const (
+	// DefaultE2EImage is the image used to run the e2e tests in a container
+	DefaultE2EImage = "python:3.11"
+
+	// e2ePath is the e2e tests directory, relative to the controller repository
+	e2ePath = "test/e2e"
+	// e2eRequirementsFile is the e2e tests requirements file, relative to the e2e
+	// tests directory
+	e2eRequirementsFile = "requirements.txt"
+
+	// Paths of the repositories, kubeconfig and reports in the e2e container
+	containerControllerPath = "/controller"
+	containerTestInfraPath  = "/test-infra"
+	containerKubeconfigPath = "/root/.kube/config"
+	containerAWSConfigPath  = "/root/.aws"
+	containerReportPath     = "/reports"
+)
+
+var (
+	// shellSafeArgRegexp matches the arguments that don't need to be quoted in a
+	// shell command
+	shellSafeArgRegexp = regexp.MustCompile(`^[A-Za-z0-9_./=:,@%+-]+$`)
+)
+
+// E2EOptions contains the options used to run the e2e tests of a controller.
+type E2EOptions struct {
+	// Service is the AWS service name, e.g s3
+	Service string
+	// ControllerPath is the path of the controller local clone
+	ControllerPath string
+	// TestInfraPath is the path of the test-infra local clone. Its acktest
+	// package replaces the one required by the controller tests.
+	TestInfraPath string
+	// Kubeconfig is the kubeconfig of the cluster the controller runs in
+	Kubeconfig string
+	// ReportDirectory is the directory the JUnit XML report is written in
+	ReportDirectory string
+	// Markers is the pytest markers expression selecting the tests,
+	// e.g "canary and not slow". All the tests run if empty.
+	Markers string
+	// Env is the list of environment variables, formatted as KEY=VALUE, passed
+	// to the tests.
+	Env []string
+
+	// VirtualEnvPath is the path of the Python virtual environment the tests
+	// run in. It's created if it doesn't exist.
+	VirtualEnvPath string
+	// SkipSetup tells whether installing the tests requirements should be
+	// skipped.
+	SkipSetup bool
+
+	// Container tells whether the tests should run in a container instead
+	// of a local virtual environment.
+	Container bool
+	// Image is the container image. Defaults to DefaultE2EImage.
+	Image string
+	// AWSConfigPath is the AWS configuration directory mounted in the
+	// container, e.g ~/.aws
+	AWSConfigPath string
+}
+
+// ReportPath returns the path of the JUnit XML report of a service e2e tests.
+func (o *E2EOptions) ReportPath() string {
+	return filepath.Join(o.ReportDirectory, fmt.Sprintf("%s-e2e.xml", o.Service))
+}
+
+// RunE2E runs the e2e tests of a controller, streaming their output to
+// stdout/stderr, and returns the JUnit XML report path. The report path is also
+// returned when the tests fail.
+func RunE2E(opts *E2EOptions) (string, error) {
+	reportDirectory, err := filepath.Abs(opts.ReportDirectory)
+	if err != nil {
+		return "", err
+	}
+	opts.ReportDirectory = reportDirectory
+	err = os.MkdirAll(opts.ReportDirectory, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	if opts.Container {
+		err = asyncexec.StreamCommand("", nil, "docker", dockerRunArgs(opts))
+	} else {
+		err = runE2ELocally(opts)
+	}
+	if err != nil {
+		// The report lists the failed tests, return it along with the error
+		return opts.ReportPath(), fmt.Errorf("%s e2e tests failed: %v", opts.Service, err)
+	}
+	return opts.ReportPath(), nil
+}
+
+// runE2ELocally runs the e2e tests in a local Python virtual environment.
+func runE2ELocally(opts *E2EOptions) error {
+	e2eDir := filepath.Join(opts.ControllerPath, e2ePath)
+	python := filepath.Join(opts.VirtualEnvPath, "bin", "python")
+
+	if !opts.SkipSetup {
+		if _, err := os.Stat(python); os.IsNotExist(err) {
+			err = asyncexec.StreamCommand("", nil, "python3", []string{"-m", "venv", opts.VirtualEnvPath})
+			if err != nil {
+				return fmt.Errorf("cannot create virtual environment: %v", err)
+			}
+		}
+		for _, args := range pipInstallArgs(e2eDir, opts.TestInfraPath) {
+			err := asyncexec.StreamCommand(e2eDir, nil, python, args)
+			if err != nil {
+				return fmt.Errorf("cannot install tests requirements: %v", err)
+			}
+		}
+	}
+
+	env := append([]string{
+		"KUBECONFIG=" + opts.Kubeconfig,
+		"PYTHONPATH=" + filepath.Dir(e2eDir),
+	}, opts.Env...)
+	return asyncexec.StreamCommand(e2eDir, env, python, pytestArgs(opts.Markers, opts.ReportPath()))
+}
+
+// pipInstallArgs returns the python arguments installing the tests requirements,
+// then the local test-infra acktest package.
+func pipInstallArgs(e2eDir, testInfraPath string) [][]string {
+	return [][]string{
+		{"-m", "pip", "install", "--quiet", "-r", filepath.Join(e2eDir, e2eRequirementsFile)},
+		{"-m", "pip", "install", "--quiet", "-e", testInfraPath},
+	}
+}
+
+// pytestArgs returns the python arguments running the tests.
+func pytestArgs(markers, reportPath string) []string {
+	args := []string{
+		"-m", "pytest",
+		"-o", "log_cli=true",
+		"--log-cli-level", "INFO",
+		"--junitxml", reportPath,
+	}
+	if markers != "" {
+		args = append(args, "-m", markers)
+	}
+	return args
+}
+
+// dockerRunArgs returns the docker arguments running the e2e tests in a
+// container. The host network is used so that local clusters are reachable.
+func dockerRunArgs(opts *E2EOptions) []string {
+	image := opts.Image
+	if image == "" {
+		image = DefaultE2EImage
+	}
+	containerE2EDir := filepath.ToSlash(filepath.Join(containerControllerPath, e2ePath))
+	reportPath := fmt.Sprintf("%s/%s-e2e.xml", containerReportPath, opts.Service)
+
+	args := []string{
+		"run", "--rm", "--network", "host",
+		"-v", opts.ControllerPath + ":" + containerControllerPath,
+		"-v", opts.TestInfraPath + ":" + containerTestInfraPath,
+		"-v", opts.Kubeconfig + ":" + containerKubeconfigPath + ":ro",
+		"-v", opts.ReportDirectory + ":" + containerReportPath,
+		"-w", containerE2EDir,
+		"-e", "KUBECONFIG=" + containerKubeconfigPath,
+		"-e", "PYTHONPATH=" + filepath.ToSlash(filepath.Dir(containerE2EDir)),
+	}
+	if opts.AWSConfigPath != "" {
+		args = append(args, "-v", opts.AWSConfigPath+":"+containerAWSConfigPath+":ro")
+	}
+	for _, env := range opts.Env {
+		args = append(args, "-e", env)
+	}
+
+	commands := []string{}
+	if !opts.SkipSetup {
+		for _, pipArgs := range pipInstallArgs(containerE2EDir, containerTestInfraPath) {
+			commands = append(commands, "python "+strings.Join(pipArgs, " "))
+		}
+	}
+	commands = append(commands, "python "+shellJoin(pytestArgs(opts.Markers, reportPath)))
+	return append(args, image, "sh", "-c", strings.Join(commands, " && "))
+}
+
+// shellJoin joins arguments in a shell command, single quoting the arguments
+// containing characters the shell would interpret, and the empty ones.
+func shellJoin(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if !shellSafeArgRegexp.MatchString(arg) {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
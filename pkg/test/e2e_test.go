@@ -0,0 +1,147 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPytestArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{
+		"-m", "pytest", "-o", "log_cli=true", "--log-cli-level", "INFO",
+		"--junitxml", "/reports/s3-e2e.xml",
+	}, pytestArgs("", "/reports/s3-e2e.xml"))
+	assert.Equal([]string{
+		"-m", "pytest", "-o", "log_cli=true", "--log-cli-level", "INFO",
+		"--junitxml", "/reports/s3-e2e.xml",
+		"-m", "canary and not slow",
+	}, pytestArgs("canary and not slow", "/reports/s3-e2e.xml"))
+}
+
+func TestDockerRunArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	args := dockerRunArgs(&E2EOptions{
+		Service:         "s3",
+		ControllerPath:  "/src/s3-controller",
+		TestInfraPath:   "/src/test-infra",
+		Kubeconfig:      "/home/ack/.kube/config",
+		ReportDirectory: "/home/ack/reports",
+		Markers:         "canary",
+		Env:             []string{"AWS_REGION=us-west-2"},
+		AWSConfigPath:   "/home/ack/.aws",
+	})
+	assert.Equal([]string{
+		"run", "--rm", "--network", "host",
+		"-v", "/src/s3-controller:/controller",
+		"-v", "/src/test-infra:/test-infra",
+		"-v", "/home/ack/.kube/config:/root/.kube/config:ro",
+		"-v", "/home/ack/reports:/reports",
+		"-w", "/controller/test/e2e",
+		"-e", "KUBECONFIG=/root/.kube/config",
+		"-e", "PYTHONPATH=/controller/test",
+		"-v", "/home/ack/.aws:/root/.aws:ro",
+		"-e", "AWS_REGION=us-west-2",
+		"python:3.11", "sh", "-c",
+		"python -m pip install --quiet -r /controller/test/e2e/requirements.txt && " +
+			"python -m pip install --quiet -e /test-infra && " +
+			"python -m pytest -o log_cli=true --log-cli-level INFO --junitxml /reports/s3-e2e.xml -m canary",
+	}, args)
+
+	args = dockerRunArgs(&E2EOptions{
+		Service:         "s3",
+		ControllerPath:  "/src/s3-controller",
+		TestInfraPath:   "/src/test-infra",
+		Kubeconfig:      "/home/ack/.kube/config",
+		ReportDirectory: "/home/ack/reports",
+		Markers:         "canary and not slow",
+		SkipSetup:       true,
+		Image:           "ack-e2e:latest",
+	})
+	assert.Equal([]string{
+		"ack-e2e:latest", "sh", "-c",
+		"python -m pytest -o log_cli=true --log-cli-level INFO --junitxml /reports/s3-e2e.xml -m 'canary and not slow'",
+	}, args[len(args)-4:])
+}
+
+func TestShellJoin(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"pytest", "-m", "not slow"}, want: "pytest -m 'not slow'"},
+		{args: []string{"echo", "it's"}, want: `echo 'it'\''s'`},
+		{args: []string{"echo", `"quoted"`}, want: `echo '"quoted"'`},
+		{args: []string{"pytest", "--junitxml", "/reports/s3-e2e.xml", "-o", "log_cli=true"}, want: "pytest --junitxml /reports/s3-e2e.xml -o log_cli=true"},
+		{args: []string{"pytest", "user@host:8080,a+b%c"}, want: "pytest user@host:8080,a+b%c"},
+		{args: []string{"pytest", "-m", "(canary)"}, want: "pytest -m '(canary)'"},
+		{args: []string{"pytest", "-k", "$HOME"}, want: "pytest -k '$HOME'"},
+		{args: []string{"pytest", "-k", "a;rm"}, want: "pytest -k 'a;rm'"},
+		{args: []string{"pytest", "-k", "a&b"}, want: "pytest -k 'a&b'"},
+		{args: []string{"pytest", "-k", "a|b"}, want: "pytest -k 'a|b'"},
+		{args: []string{"pytest", "tests/*.py"}, want: "pytest 'tests/*.py'"},
+		{args: []string{"pytest", "test_[ab]?.py"}, want: "pytest 'test_[ab]?.py'"},
+		{args: []string{"pytest", "`id`", "~", "a>b"}, want: "pytest '`id`' '~' 'a>b'"},
+		{args: []string{"pytest", ""}, want: "pytest ''"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, shellJoin(tt.args))
+		})
+	}
+}
+
+func TestRunE2E(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	controllerPath := t.TempDir()
+	require.NoError(os.MkdirAll(filepath.Join(controllerPath, e2ePath), 0755))
+
+	// fake virtual environment python recording its arguments and environment
+	venvPath := t.TempDir()
+	outputPath := filepath.Join(t.TempDir(), "output")
+	require.NoError(os.MkdirAll(filepath.Join(venvPath, "bin"), 0755))
+	script := "#!/bin/sh\necho \"$@ $KUBECONFIG $AWS_REGION\" > " + outputPath + "\n"
+	require.NoError(ioutil.WriteFile(filepath.Join(venvPath, "bin", "python"), []byte(script), 0755))
+
+	reportDirectory := filepath.Join(t.TempDir(), "reports")
+	reportPath, err := RunE2E(&E2EOptions{
+		Service:         "s3",
+		ControllerPath:  controllerPath,
+		Kubeconfig:      "/tmp/kubeconfig",
+		ReportDirectory: reportDirectory,
+		Env:             []string{"AWS_REGION=us-west-2"},
+		VirtualEnvPath:  venvPath,
+		SkipSetup:       true,
+	})
+	require.NoError(err)
+	assert.Equal(filepath.Join(reportDirectory, "s3-e2e.xml"), reportPath)
+	assert.DirExists(reportDirectory)
+
+	output, err := ioutil.ReadFile(outputPath)
+	require.NoError(err)
+	assert.Equal("-m pytest -o log_cli=true --log-cli-level INFO --junitxml "+reportPath+" /tmp/kubeconfig us-west-2\n", string(output))
+}
@@ -0,0 +1,109 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName    xml.Name          `xml:"testsuites"`
+	Tests      int               `xml:"tests,attr"`
+	Failures   int               `xml:"failures,attr"`
+	Errors     int               `xml:"errors,attr"`
+	Skipped    int               `xml:"skipped,attr"`
+	TestSuites []*junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite contains the test cases of a repository
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      string           `xml:"time,attr"`
+	Error     *junitMessage    `xml:"error,omitempty"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// WriteJUnitReport writes the unit tests results as a JUnit XML report, with a
+// test suite per repository.
+func WriteJUnitReport(w io.Writer, results UnitResults) error {
+	report := &junitTestSuites{}
+	for _, result := range results {
+		suite := &junitTestSuite{
+			Name:     result.Repository,
+			Tests:    len(result.TestCases),
+			Failures: result.Failed,
+			Skipped:  result.Skipped,
+			Time:     fmt.Sprintf("%.3f", result.Duration.Seconds()),
+		}
+		if result.Err != nil {
+			suite.Errors = 1
+			suite.Error = &junitMessage{Message: result.Err.Error()}
+		}
+		for _, testCase := range result.TestCases {
+			junitCase := &junitTestCase{
+				ClassName: testCase.Package,
+				Name:      testCase.Name,
+				Time:      fmt.Sprintf("%.3f", testCase.Elapsed.Seconds()),
+			}
+			switch testCase.Status {
+			case StatusFailed:
+				junitCase.Failure = &junitMessage{Message: "Failed", Content: testCase.Output}
+			case StatusSkipped:
+				junitCase.Skipped = &junitMessage{Message: "Skipped"}
+			}
+			if junitCase.Name == "" {
+				// Package failing without failed test, e.g build failure
+				junitCase.Name = testCase.Package
+			}
+			suite.TestCases = append(suite.TestCases, junitCase)
+		}
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		report.TestSuites = append(report.TestSuites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
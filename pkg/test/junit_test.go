@@ -0,0 +1,52 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJUnitReport(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	result, err := ParseTestEvents(strings.NewReader(testEvents))
+	require.NoError(err)
+	result.Repository = "s3-controller"
+	result.Duration = 1500 * time.Millisecond
+
+	var b bytes.Buffer
+	err = WriteJUnitReport(&b, UnitResults{
+		result,
+		{Repository: "ecr-controller", Err: errors.New("go: cannot find main module")},
+	})
+	require.NoError(err)
+
+	report := b.String()
+	assert.True(strings.HasPrefix(report, "<?xml"))
+	assert.Contains(report, `<testsuites tests="4" failures="2" errors="1" skipped="1">`)
+	assert.Contains(report, `<testsuite name="s3-controller" tests="4" failures="2" errors="0" skipped="1" time="1.500">`)
+	assert.Contains(report, `<testcase classname="example.com/a" name="TestPass" time="0.500"></testcase>`)
+	assert.Contains(report, `<failure message="Failed">a_test.go:10: expected 1&#xA;</failure>`)
+	assert.Contains(report, `<testcase classname="example.com/b" name="example.com/b" time="0.000">`)
+	assert.Contains(report, `<skipped message="Skipped"></skipped>`)
+	assert.Contains(report, `<error message="go: cannot find main module"></error>`)
+}
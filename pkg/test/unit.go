@@ -0,0 +1,316 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+const (
+	goBinary = "go"
+
+	// Test event actions emitted by go test -json
+	// https://pkg.go.dev/cmd/test2json
+	actionPass   = "pass"
+	actionFail   = "fail"
+	actionSkip   = "skip"
+	actionOutput = "output"
+	// actionBuildOutput is emitted by recent Go versions for build errors
+	actionBuildOutput = "build-output"
+)
+
+// Test cases status
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+var (
+	// ErrTestsFailed is returned when the unit tests of at least one repository
+	// failed.
+	ErrTestsFailed = errors.New("unit tests failed")
+)
+
+// testEvent is an event emitted by go test -json
+type testEvent struct {
+	Time    time.Time
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+	// ImportPath is set on build events, e.g "example.com/a [example.com/a.test]"
+	ImportPath string
+}
+
+// TestCase is the result of a top level test function. Packages failing
+// without any failed test (e.g build failures) are reported as a test case
+// without name.
+type TestCase struct {
+	Package string        `json:"package"`
+	Name    string        `json:"name,omitempty"`
+	Status  string        `json:"status"`
+	Elapsed time.Duration `json:"elapsed"`
+	// Output is the output of the test, only kept for failed tests
+	Output string `json:"output,omitempty"`
+}
+
+// FullName returns the test case name prefixed by its package.
+func (c *TestCase) FullName() string {
+	if c.Name == "" {
+		return c.Package
+	}
+	return c.Package + "." + c.Name
+}
+
+// UnitResult is the result of the unit tests of a repository.
+type UnitResult struct {
+	Repository string        `json:"repository"`
+	Passed     int           `json:"passed"`
+	Failed     int           `json:"failed"`
+	Skipped    int           `json:"skipped"`
+	Duration   time.Duration `json:"duration"`
+	TestCases  []*TestCase   `json:"testCases,omitempty"`
+	// Err is set when the tests couldn't run
+	Err error `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, exposing Err as a string.
+func (r *UnitResult) MarshalJSON() ([]byte, error) {
+	type alias UnitResult
+	var errMessage string
+	if r.Err != nil {
+		errMessage = r.Err.Error()
+	}
+	return json.Marshal(&struct {
+		*alias
+		FailedTests []string `json:"failedTests,omitempty"`
+		Error       string   `json:"error,omitempty"`
+	}{
+		alias:       (*alias)(r),
+		FailedTests: r.FailedTests(),
+		Error:       errMessage,
+	})
+}
+
+// FailedTests returns the full names of the failed test cases.
+func (r *UnitResult) FailedTests() []string {
+	failed := []string{}
+	for _, c := range r.TestCases {
+		if c.Status == StatusFailed {
+			failed = append(failed, c.FullName())
+		}
+	}
+	return failed
+}
+
+// Succeeded returns true if the tests ran and none of them failed.
+func (r *UnitResult) Succeeded() bool {
+	return r.Err == nil && r.Failed == 0
+}
+
+// UnitResults is the list of results returned by RunUnitTestsAll
+type UnitResults []*UnitResult
+
+// Succeeded returns true if the tests of all the repositories succeeded.
+func (r UnitResults) Succeeded() bool {
+	for _, result := range r {
+		if !result.Succeeded() {
+			return false
+		}
+	}
+	return true
+}
+
+// RunUnitTestsAll runs the unit tests of the given repositories, using at most
+// maxWorkers repositories in parallel. The returned results are in the same
+// order as repos.
+func RunUnitTestsAll(ctx context.Context, repos []*repository.Repository, maxWorkers int) UnitResults {
+	results := make(UnitResults, len(repos))
+	repository.ForEach(repos, maxWorkers, func(i int, repo *repository.Repository) {
+		result, err := RunUnitTests(ctx, repo.FullPath)
+		if err != nil {
+			result = &UnitResult{Err: err}
+		}
+		result.Repository = repo.Name
+		results[i] = result
+	})
+	return results
+}
+
+// RunUnitTests runs go test -json ./... in a Go module directory and parses
+// its output. Failing tests don't return an error, they are reported in the
+// result.
+func RunUnitTests(ctx context.Context, path string) (*UnitResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, goBinary, "test", "-json", "./...")
+	cmd.Dir = path
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	result, parseErr := ParseTestEvents(stdout)
+	// Drain stdout so that the command doesn't block on a parse error
+	_, _ = io.Copy(ioutil.Discard, stdout)
+	waitErr := cmd.Wait()
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	result.Duration = time.Since(start).Round(time.Millisecond)
+
+	var exitErr *exec.ExitError
+	if waitErr != nil && !errors.As(waitErr, &exitErr) {
+		return nil, waitErr
+	}
+	// go test exits with a non zero code when tests fail. If nothing failed,
+	// the tests didn't run at all.
+	if waitErr != nil && result.Failed == 0 {
+		return nil, fmt.Errorf("go test failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return result, nil
+}
+
+// ParseTestEvents parses the test events emitted by go test -json and returns
+// the results of the top level tests. Subtests are accounted in their parent
+// test.
+func ParseTestEvents(r io.Reader) (*UnitResult, error) {
+	result := &UnitResult{}
+	cases := map[string]*TestCase{}
+	outputs := map[string]*strings.Builder{}
+	failedPackages := map[string]bool{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		event := &testEvent{}
+		if err := json.Unmarshal(line, event); err != nil {
+			return nil, fmt.Errorf("cannot parse test event %q: %v", line, err)
+		}
+
+		if event.Action == actionBuildOutput {
+			event.Package = strings.Fields(event.ImportPath + " ")[0]
+			event.Action = actionOutput
+		}
+		testName := strings.SplitN(event.Test, "/", 2)[0]
+		key := event.Package + "." + testName
+		if event.Action == actionOutput {
+			if _, ok := outputs[key]; !ok {
+				outputs[key] = &strings.Builder{}
+			}
+			outputs[key].WriteString(event.Output)
+			continue
+		}
+		if event.Action != actionPass && event.Action != actionFail && event.Action != actionSkip {
+			continue
+		}
+
+		if event.Test == "" {
+			if event.Action == actionFail {
+				failedPackages[event.Package] = true
+			}
+			continue
+		}
+		// Only the top level tests are reported
+		if testName != event.Test {
+			continue
+		}
+		testCase := &TestCase{
+			Package: event.Package,
+			Name:    testName,
+			Status:  eventStatus(event.Action),
+			Elapsed: time.Duration(event.Elapsed * float64(time.Second)),
+		}
+		cases[key] = testCase
+		result.TestCases = append(result.TestCases, testCase)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Report the packages failing without any failed test
+	for _, testCase := range cases {
+		if testCase.Status == StatusFailed {
+			delete(failedPackages, testCase.Package)
+		}
+	}
+	for _, testCase := range result.TestCases {
+		if testCase.Status == StatusFailed {
+			if output, ok := outputs[testCase.Package+"."+testCase.Name]; ok {
+				testCase.Output = output.String()
+			}
+		}
+	}
+	packages := make([]string, 0, len(failedPackages))
+	for pkg := range failedPackages {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+	for _, pkg := range packages {
+		testCase := &TestCase{Package: pkg, Status: StatusFailed}
+		if output, ok := outputs[pkg+"."]; ok {
+			testCase.Output = output.String()
+		}
+		result.TestCases = append(result.TestCases, testCase)
+	}
+
+	for _, testCase := range result.TestCases {
+		switch testCase.Status {
+		case StatusPassed:
+			result.Passed++
+		case StatusFailed:
+			result.Failed++
+		case StatusSkipped:
+			result.Skipped++
+		}
+	}
+	return result, nil
+}
+
+func eventStatus(action string) string {
+	switch action {
+	case actionPass:
+		return StatusPassed
+	case actionFail:
+		return StatusFailed
+	}
+	return StatusSkipped
+}
@@ -0,0 +1,135 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+const testEvents = `
+{"Action":"run","Package":"example.com/a","Test":"TestPass"}
+{"Action":"output","Package":"example.com/a","Test":"TestPass","Output":"=== RUN   TestPass\n"}
+{"Action":"pass","Package":"example.com/a","Test":"TestPass","Elapsed":0.5}
+{"Action":"run","Package":"example.com/a","Test":"TestFail"}
+{"Action":"run","Package":"example.com/a","Test":"TestFail/sub"}
+{"Action":"output","Package":"example.com/a","Test":"TestFail/sub","Output":"a_test.go:10: expected 1\n"}
+{"Action":"fail","Package":"example.com/a","Test":"TestFail/sub","Elapsed":0}
+{"Action":"fail","Package":"example.com/a","Test":"TestFail","Elapsed":0.01}
+{"Action":"skip","Package":"example.com/a","Test":"TestSkip","Elapsed":0}
+{"Action":"fail","Package":"example.com/a","Elapsed":1.2}
+{"ImportPath":"example.com/b","Action":"build-output","Output":"b.go:3:1: syntax error\n"}
+{"Action":"fail","Package":"example.com/b","Elapsed":0}
+{"Action":"skip","Package":"example.com/c","Elapsed":0}
+`
+
+func TestParseTestEvents(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	result, err := ParseTestEvents(strings.NewReader(testEvents))
+	require.NoError(err)
+	assert.Equal(1, result.Passed)
+	assert.Equal(2, result.Failed)
+	assert.Equal(1, result.Skipped)
+	assert.Equal([]string{"example.com/a.TestFail", "example.com/b"}, result.FailedTests())
+	require.Len(result.TestCases, 4)
+	assert.Equal(500*time.Millisecond, result.TestCases[0].Elapsed)
+	assert.Empty(result.TestCases[0].Output)
+	assert.Equal("a_test.go:10: expected 1\n", result.TestCases[1].Output)
+	assert.Equal("b.go:3:1: syntax error\n", result.TestCases[3].Output)
+	assert.False(result.Succeeded())
+
+	_, err = ParseTestEvents(strings.NewReader("not json"))
+	assert.Error(err)
+}
+
+func TestUnitResult_MarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	result, err := ParseTestEvents(strings.NewReader(testEvents))
+	require.NoError(err)
+	result.Repository = "s3-controller"
+
+	b, err := json.Marshal(UnitResults{result, {Repository: "ecr-controller", Err: context.Canceled}})
+	require.NoError(err)
+	var decoded []map[string]interface{}
+	require.NoError(json.Unmarshal(b, &decoded))
+	require.Len(decoded, 2)
+	assert.Equal("s3-controller", decoded[0]["repository"])
+	assert.Equal([]interface{}{"example.com/a.TestFail", "example.com/b"}, decoded[0]["failedTests"])
+	assert.NotContains(decoded[0], "error")
+	assert.Equal("context canceled", decoded[1]["error"])
+}
+
+func writeModule(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	files["go.mod"] = "module example.com/unit\n\ngo 1.21\n"
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestRunUnitTestsAll(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go test invocations in short mode")
+	}
+	assert := assert.New(t)
+	require := require.New(t)
+
+	passing := writeModule(t, map[string]string{
+		"a_test.go": "package unit\n\nimport \"testing\"\n\nfunc TestPass(t *testing.T) {}\n",
+	})
+	failing := writeModule(t, map[string]string{
+		"a_test.go": "package unit\n\nimport \"testing\"\n\nfunc TestPass(t *testing.T) {}\n\nfunc TestFail(t *testing.T) { t.Fatal(\"boom\") }\n",
+	})
+	notModule := t.TempDir()
+
+	results := RunUnitTestsAll(context.TODO(), []*repository.Repository{
+		{Name: "passing", FullPath: passing},
+		{Name: "failing", FullPath: failing},
+		{Name: "not-module", FullPath: notModule},
+	}, 2)
+	require.Len(results, 3)
+	assert.False(results.Succeeded())
+
+	assert.Equal("passing", results[0].Repository)
+	assert.NoError(results[0].Err)
+	assert.Equal(1, results[0].Passed)
+	assert.True(results[0].Succeeded())
+
+	assert.Equal("failing", results[1].Repository)
+	assert.NoError(results[1].Err)
+	assert.Equal(1, results[1].Passed)
+	assert.Equal(1, results[1].Failed)
+	assert.Equal([]string{"example.com/unit.TestFail"}, results[1].FailedTests())
+	assert.Contains(results[1].TestCases[1].Output, "boom")
+
+	// Depending on the Go version, the setup failure is reported as an error
+	// or as a failed package
+	assert.Equal("not-module", results[2].Repository)
+	assert.False(results[2].Succeeded())
+}
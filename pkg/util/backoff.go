@@ -0,0 +1,64 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util
+
+import (
+	"context"
+	"time"
+)
+
+// Backoff computes exponentially growing wait durations, starting at Initial
+// and capped at Max.
+type Backoff struct {
+	// Initial is the first wait duration
+	Initial time.Duration
+	// Max is the maximum wait duration. No limit if zero.
+	Max time.Duration
+	// Factor is the multiplier applied after each wait. Defaults to 2.
+	Factor float64
+
+	next time.Duration
+}
+
+// Next returns the next wait duration.
+func (b *Backoff) Next() time.Duration {
+	if b.next == 0 {
+		b.next = b.Initial
+	}
+	current := b.next
+
+	factor := b.Factor
+	if factor == 0 {
+		factor = 2
+	}
+	b.next = time.Duration(float64(b.next) * factor)
+	if b.Max > 0 && b.next > b.Max {
+		b.next = b.Max
+	}
+	return current
+}
+
+// Sleep waits for the given duration or until the context is done, in which
+// case it returns the context error.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
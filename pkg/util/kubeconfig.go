@@ -0,0 +1,70 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ghodss/yaml"
+)
+
+// WriteKubeconfigWithContext copies a kubeconfig file to a temporary file, with its
+// current context set to kubeContext, and returns the temporary file path. The
+// caller is responsible for removing the file.
+func WriteKubeconfigWithContext(kubeconfigPath, kubeContext string) (string, error) {
+	b, err := ioutil.ReadFile(kubeconfigPath)
+	if err != nil {
+		return "", err
+	}
+	kubeconfig := map[string]interface{}{}
+	err = yaml.Unmarshal(b, &kubeconfig)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse kubeconfig %s: %v", kubeconfigPath, err)
+	}
+
+	if !hasKubeContext(kubeconfig, kubeContext) {
+		return "", fmt.Errorf("context %s not found in kubeconfig %s", kubeContext, kubeconfigPath)
+	}
+	kubeconfig["current-context"] = kubeContext
+
+	b, err = yaml.Marshal(kubeconfig)
+	if err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile("", "ackdev-kubeconfig-")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	_, err = f.Write(b)
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// hasKubeContext returns true if the kubeconfig contains the given context.
+func hasKubeContext(kubeconfig map[string]interface{}, kubeContext string) bool {
+	contexts, _ := kubeconfig["contexts"].([]interface{})
+	for _, c := range contexts {
+		context, _ := c.(map[string]interface{})
+		if name, _ := context["name"].(string); name == kubeContext {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: kind-ack
+clusters:
+- name: kind-ack
+  cluster:
+    server: https://127.0.0.1:6443
+- name: staging
+  cluster:
+    server: https://staging.example.com
+contexts:
+- name: kind-ack
+  context:
+    cluster: kind-ack
+    user: kind-ack
+- name: staging
+  context:
+    cluster: staging
+    user: staging
+users:
+- name: kind-ack
+- name: staging
+`
+
+func TestWriteKubeconfigWithContext(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kubeconfigPath := filepath.Join(t.TempDir(), "config")
+	require.NoError(ioutil.WriteFile(kubeconfigPath, []byte(testKubeconfig), 0600))
+
+	path, err := WriteKubeconfigWithContext(kubeconfigPath, "staging")
+	require.NoError(err)
+	defer os.Remove(path)
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	kubeconfig := map[string]interface{}{}
+	require.NoError(yaml.Unmarshal(b, &kubeconfig))
+	assert.Equal("staging", kubeconfig["current-context"])
+	assert.Len(kubeconfig["contexts"], 2)
+
+	_, err = WriteKubeconfigWithContext(kubeconfigPath, "production")
+	assert.Error(err)
+	_, err = WriteKubeconfigWithContext(filepath.Join(t.TempDir(), "missing"), "staging")
+	assert.Error(err)
+}
@@ -18,15 +18,17 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"strings"
 
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/terminal"
 )
 
-// newSigner returns a ssh.Signer from a PEM encoded private key path.
-// If the PEM file is encrypted it will try to read the passphrase from
-// a terminal without local echo.
+// NewSigner returns a ssh.Signer from a PEM encoded private key path. Both
+// PKCS#1/PKCS#8 and OpenSSH private key formats are supported. If the private
+// key is encrypted it will try to read the passphrase from a terminal without
+// local echo.
 func NewSigner(sshKeyPath string) (ssh.Signer, error) {
 	pemBytes, err := ioutil.ReadFile(sshKeyPath)
 	if err != nil {
@@ -35,19 +37,24 @@ func NewSigner(sshKeyPath string) (ssh.Signer, error) {
 
 	block, _ := pem.Decode(pemBytes)
 	if block == nil {
-		return nil, errors.New("invalid ssh certificate")
+		return nil, errors.New("invalid ssh private key")
 	}
 
-	if encryptedBlock(block) {
-		passphrase, err := promptPassphrase()
-		if err != nil {
-			return nil, err
+	// OpenSSH private keys don't expose their encryption in the PEM headers,
+	// the parser tells us if a passphrase is needed.
+	if !encryptedBlock(block) {
+		signer, err := ssh.ParsePrivateKey(pemBytes)
+		var passphraseMissingErr *ssh.PassphraseMissingError
+		if !errors.As(err, &passphraseMissingErr) {
+			return signer, err
 		}
-
-		return ssh.ParsePrivateKeyWithPassphrase(pemBytes, passphrase)
 	}
 
-	return ssh.ParsePrivateKey(pemBytes)
+	passphrase, err := promptPassphrase(sshKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	return ssh.ParsePrivateKeyWithPassphrase(pemBytes, passphrase)
 }
 
 // encryptedBlock tells whether a private key is
@@ -58,9 +65,12 @@ func encryptedBlock(block *pem.Block) bool {
 	return strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED")
 }
 
-func promptPassphrase() ([]byte, error) {
-	fmt.Printf("type your ssh key passphrase:")
-	passphrase, err := terminal.ReadPassword(0)
+// promptPassphrase reads an ssh key passphrase from the terminal. It's a
+// variable to allow overriding it in tests.
+var promptPassphrase = func(sshKeyPath string) ([]byte, error) {
+	fmt.Printf("type the passphrase of %s: ", sshKeyPath)
+	passphrase, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,103 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestNewSigner(t *testing.T) {
+	require := require.New(t)
+
+	passphrase := []byte("ramanujan")
+	originalPromptPassphrase := promptPassphrase
+	t.Cleanup(func() { promptPassphrase = originalPromptPassphrase })
+	promptPassphrase = func(string) ([]byte, error) { return passphrase, nil }
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(err)
+
+	openSSHBlock, err := ssh.MarshalPrivateKey(edKey, "")
+	require.NoError(err)
+	encryptedOpenSSHBlock, err := ssh.MarshalPrivateKeyWithPassphrase(edKey, "", passphrase)
+	require.NoError(err)
+	pkcs1Block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}
+	// Legacy encrypted PEM keys are still generated by older ssh-keygen versions.
+	encryptedPKCS1Block, err := x509.EncryptPEMBlock(rand.Reader, pkcs1Block.Type, pkcs1Block.Bytes, passphrase, x509.PEMCipherAES256)
+	require.NoError(err)
+
+	dir := t.TempDir()
+	writeKey := func(name string, content []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(os.WriteFile(path, content, 0600))
+		return path
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{
+			name:    "missing key",
+			path:    filepath.Join(dir, "missing"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid key",
+			path:    writeKey("invalid", []byte("not a key")),
+			wantErr: true,
+		},
+		{
+			name: "openssh key",
+			path: writeKey("id_ed25519", pem.EncodeToMemory(openSSHBlock)),
+		},
+		{
+			name: "encrypted openssh key",
+			path: writeKey("id_ed25519_encrypted", pem.EncodeToMemory(encryptedOpenSSHBlock)),
+		},
+		{
+			name: "pkcs1 key",
+			path: writeKey("id_rsa", pem.EncodeToMemory(pkcs1Block)),
+		},
+		{
+			name: "encrypted pkcs1 key",
+			path: writeKey("id_rsa_encrypted", pem.EncodeToMemory(encryptedPKCS1Block)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := NewSigner(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, signer)
+		})
+	}
+}
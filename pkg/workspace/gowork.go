@@ -0,0 +1,103 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// GoWorkFileName is the name of the Go workspace file
+	GoWorkFileName = "go.work"
+)
+
+// GoWorkPath returns the path of the go.work file of a root directory.
+func GoWorkPath(root string) string {
+	return filepath.Join(root, GoWorkFileName)
+}
+
+// Exists returns true if the root directory contains a go.work file.
+func Exists(root string) (bool, error) {
+	_, err := os.Stat(GoWorkPath(root))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Use adds module directories to the go.work file of a root directory,
+// creating the file if needed. The directories can be absolute or relative
+// to the root directory.
+func Use(root string, dirs ...string) error {
+	exists, err := Exists(root)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		if err := goWork(root, "init"); err != nil {
+			return err
+		}
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+	return goWork(root, append([]string{"use"}, relativeDirs(root, dirs)...)...)
+}
+
+// DropUse removes module directories from the go.work file of a root
+// directory. It's a no-op if the file doesn't exist.
+func DropUse(root string, dirs ...string) error {
+	exists, err := Exists(root)
+	if err != nil || !exists || len(dirs) == 0 {
+		return err
+	}
+	args := []string{"edit"}
+	for _, dir := range relativeDirs(root, dirs) {
+		args = append(args, "-dropuse="+dir)
+	}
+	return goWork(root, args...)
+}
+
+// relativeDirs returns the directories relative to the root directory, the
+// way go work use writes them.
+func relativeDirs(root string, dirs []string) []string {
+	relative := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if filepath.IsAbs(dir) {
+			if rel, err := filepath.Rel(root, dir); err == nil && !strings.HasPrefix(rel, "..") {
+				dir = rel
+			}
+		}
+		if !filepath.IsAbs(dir) && !strings.HasPrefix(dir, ".") {
+			dir = "." + string(filepath.Separator) + dir
+		}
+		relative = append(relative, filepath.ToSlash(dir))
+	}
+	return relative
+}
+
+// goWork runs a go work subcommand in the root directory.
+func goWork(root string, args ...string) error {
+	cmd := exec.Command("go", append([]string{"work"}, args...)...)
+	cmd.Dir = root
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("go work %s failed: %v: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeModule(t *testing.T, root, name string) string {
+	dir := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	goMod := "module github.com/aws-controllers-k8s/" + name + "\n\ngo 1.21\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644))
+	return dir
+}
+
+func TestUseAndDropUse(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	root := t.TempDir()
+	runtimePath := writeModule(t, root, "runtime")
+	writeModule(t, root, "s3-controller")
+
+	exists, err := Exists(root)
+	require.NoError(err)
+	assert.False(exists)
+	// dropping from a missing workspace is a no-op
+	require.NoError(DropUse(root, runtimePath))
+
+	require.NoError(Use(root, runtimePath, "s3-controller"))
+	content, err := ioutil.ReadFile(GoWorkPath(root))
+	require.NoError(err)
+	assert.Contains(string(content), "./runtime")
+	assert.Contains(string(content), "./s3-controller")
+
+	require.NoError(DropUse(root, "s3-controller"))
+	content, err = ioutil.ReadFile(GoWorkPath(root))
+	require.NoError(err)
+	assert.Contains(string(content), "./runtime")
+	assert.NotContains(string(content), "./s3-controller")
+
+	assert.Error(Use(root, "missing-controller"))
+}
+
+func TestRelativeDirs(t *testing.T) {
+	assert.Equal(t,
+		[]string{"./runtime", "./s3-controller", "../other", "./runtime"},
+		relativeDirs("/src/ack", []string{"/src/ack/runtime", "s3-controller", "../other", "./runtime"}),
+	)
+}
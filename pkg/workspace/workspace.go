@@ -0,0 +1,157 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+	"github.com/aws-controllers-k8s/dev-tools/pkg/util"
+)
+
+const (
+	// defaultGoVersion is the go directive of workspaces whose modules don't
+	// declare a Go version.
+	defaultGoVersion = "1.21"
+)
+
+// ModuleDirs returns the directories of the repositories that can be added to
+// a workspace: the cloned Go modules that aren't excluded.
+func ModuleDirs(repos []*repository.Repository, exclude []string) []string {
+	dirs := []string{}
+	for _, repo := range repos {
+		if repo.GitHead == "" || util.InStrings(repo.Name, exclude) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(repo.FullPath, "go.mod")); err != nil {
+			continue
+		}
+		dirs = append(dirs, repo.FullPath)
+	}
+	return dirs
+}
+
+// Generate returns the content of a go.work file using the given Go version and
+// module directories. The directories must be relative to the workspace root.
+func Generate(goVersion string, dirs []string) []byte {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by ackdev. DO NOT EDIT.\n")
+	b.WriteString("// Run 'ackdev workspace sync' to update it.\n\n")
+	fmt.Fprintf(&b, "go %s\n", goVersion)
+	if len(dirs) > 0 {
+		b.WriteString("\nuse (\n")
+		for _, dir := range dirs {
+			fmt.Fprintf(&b, "\t%s\n", dir)
+		}
+		b.WriteString(")\n")
+	}
+	return b.Bytes()
+}
+
+// Sync writes the go.work file of a root directory so that it contains exactly
+// the given module directories. The go directive is the highest Go version
+// required by the modules or the existing go.work file. It returns true if the
+// file was created or modified.
+func Sync(root string, moduleDirs []string) (bool, error) {
+	goVersion := defaultGoVersion
+	goWorkPath := GoWorkPath(root)
+	if v, err := goDirective(goWorkPath); err == nil && compareGoVersions(v, goVersion) > 0 {
+		goVersion = v
+	} else if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	seen := map[string]bool{}
+	dirs := []string{}
+	for i, dir := range relativeDirs(root, moduleDirs) {
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		dirs = append(dirs, dir)
+
+		moduleDir := moduleDirs[i]
+		if !filepath.IsAbs(moduleDir) {
+			moduleDir = filepath.Join(root, moduleDir)
+		}
+		v, err := goDirective(filepath.Join(moduleDir, "go.mod"))
+		if err != nil {
+			return false, fmt.Errorf("cannot read %s go.mod: %v", dir, err)
+		}
+		if compareGoVersions(v, goVersion) > 0 {
+			goVersion = v
+		}
+	}
+	sort.Strings(dirs)
+
+	content := Generate(goVersion, dirs)
+	existing, err := ioutil.ReadFile(goWorkPath)
+	if err == nil && bytes.Equal(existing, content) {
+		return false, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	return true, ioutil.WriteFile(goWorkPath, content, 0644)
+}
+
+// goDirective returns the Go version of a go.mod or go.work file. An empty
+// string is returned if the file doesn't declare it.
+func goDirective(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "go" {
+			return fields[1], nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// compareGoVersions compares two Go versions, e.g 1.21 and 1.21.5, and returns
+// -1, 0 or 1 if a is respectively lower, equal or greater than b.
+func compareGoVersions(a, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var x, y int
+		if i < len(aParts) {
+			x, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			y, _ = strconv.Atoi(bParts[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
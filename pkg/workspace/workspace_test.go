@@ -0,0 +1,98 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package workspace
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws-controllers-k8s/dev-tools/pkg/repository"
+)
+
+func TestModuleDirs(t *testing.T) {
+	root := t.TempDir()
+	runtimePath := writeModule(t, root, "runtime")
+	s3Path := writeModule(t, root, "s3-controller")
+	ecrPath := writeModule(t, root, "ecr-controller")
+
+	dirs := ModuleDirs([]*repository.Repository{
+		{Name: "runtime", FullPath: runtimePath, GitHead: "main"},
+		{Name: "s3-controller", FullPath: s3Path, GitHead: "main"},
+		{Name: "ecr-controller", FullPath: ecrPath, GitHead: "main"},
+		// not cloned
+		{Name: "sns-controller", FullPath: filepath.Join(root, "sns-controller")},
+		// not a Go module
+		{Name: "test-infra", FullPath: root, GitHead: "main"},
+	}, []string{"ecr-controller"})
+	assert.Equal(t, []string{runtimePath, s3Path}, dirs)
+}
+
+func TestGenerate(t *testing.T) {
+	assert.Equal(t, `// Code generated by ackdev. DO NOT EDIT.
+// Run 'ackdev workspace sync' to update it.
+
+go 1.21
+
+use (
+	./runtime
+	./s3-controller
+)
+`, string(Generate("1.21", []string{"./runtime", "./s3-controller"})))
+}
+
+func TestSync(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	root := t.TempDir()
+	runtimePath := writeModule(t, root, "runtime")
+	s3Path := writeModule(t, root, "s3-controller")
+	require.NoError(ioutil.WriteFile(filepath.Join(s3Path, "go.mod"), []byte("module s3\n\ngo 1.22.1\n"), 0644))
+
+	changed, err := Sync(root, []string{s3Path, runtimePath, runtimePath})
+	require.NoError(err)
+	assert.True(changed)
+	content, err := ioutil.ReadFile(GoWorkPath(root))
+	require.NoError(err)
+	assert.Equal(string(Generate("1.22.1", []string{"./runtime", "./s3-controller"})), string(content))
+
+	changed, err = Sync(root, []string{runtimePath, s3Path})
+	require.NoError(err)
+	assert.False(changed)
+
+	// the go version of the existing workspace is kept
+	changed, err = Sync(root, []string{runtimePath})
+	require.NoError(err)
+	assert.True(changed)
+	content, err = ioutil.ReadFile(GoWorkPath(root))
+	require.NoError(err)
+	assert.Equal(string(Generate("1.22.1", []string{"./runtime"})), string(content))
+
+	_, err = Sync(root, []string{filepath.Join(root, "missing")})
+	assert.Error(err)
+}
+
+func TestCompareGoVersions(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, compareGoVersions("1.21", "1.21"))
+	assert.Equal(0, compareGoVersions("1.21", "1.21.0"))
+	assert.Equal(-1, compareGoVersions("1.21", "1.21.5"))
+	assert.Equal(1, compareGoVersions("1.22", "1.21.5"))
+	assert.Equal(-1, compareGoVersions("1.9", "1.21"))
+}